@@ -0,0 +1,201 @@
+package timetable
+
+import (
+	"cmp"
+	"math"
+	"slices"
+)
+
+// Number is the set of value types that support arithmetic helpers such as interpolation and Sum.
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 |
+		~float32 | ~float64
+}
+
+// Float is the set of value types for helpers that only make sense on real numbers, such as returns.
+type Float interface {
+	~float32 | ~float64
+}
+
+// Aggregation combines several values into one.
+// An Aggregation must not modify or retain the slice passed to it.
+// Each of the aggregations in this package returns the zero value for an empty slice.
+type Aggregation[Value any] func(values []Value) Value
+
+// First returns the first value.
+func First[Value any](values []Value) Value {
+	if len(values) == 0 {
+		var zero Value
+		return zero
+	}
+	return values[0]
+}
+
+// Last returns the last value.
+func Last[Value any](values []Value) Value {
+	if len(values) == 0 {
+		var zero Value
+		return zero
+	}
+	return values[len(values)-1]
+}
+
+// Sum returns the sum of the values.
+func Sum[Value Number](values []Value) Value {
+	var sum Value
+	for _, value := range values {
+		sum += value
+	}
+	return sum
+}
+
+// Mean returns the arithmetic mean of the values. Integer means are truncated.
+// The values are summed in a 64-bit type, so small integer types do not overflow.
+func Mean[Value Number](values []Value) Value {
+	if len(values) == 0 {
+		return 0
+	}
+	sum := newWideSum[Value]()
+	for _, value := range values {
+		sum.add(value)
+	}
+	return sum.mean(len(values))
+}
+
+type numberKind int
+
+const (
+	floatKind numberKind = iota
+	signedKind
+	unsignedKind
+)
+
+func kindOf[Value Number]() numberKind {
+	var zero, one Value = 0, 1
+	switch {
+	case one/2 != 0:
+		return floatKind
+	case zero-one > 0:
+		return unsignedKind
+	default:
+		return signedKind
+	}
+}
+
+// wideSum adds values in float64, int64 or uint64 depending on the kind of Value.
+type wideSum[Value Number] struct {
+	kind     numberKind
+	float    float64
+	signed   int64
+	unsigned uint64
+}
+
+func newWideSum[Value Number]() wideSum[Value] { return wideSum[Value]{kind: kindOf[Value]()} }
+
+func (s *wideSum[Value]) add(value Value) {
+	switch s.kind {
+	case floatKind:
+		s.float += float64(value)
+	case signedKind:
+		s.signed += int64(value)
+	default:
+		s.unsigned += uint64(value)
+	}
+}
+
+func (s *wideSum[Value]) subtract(value Value) {
+	switch s.kind {
+	case floatKind:
+		s.float -= float64(value)
+	case signedKind:
+		s.signed -= int64(value)
+	default:
+		s.unsigned -= uint64(value)
+	}
+}
+
+// mean divides the sum by count, truncating integer results.
+func (s *wideSum[Value]) mean(count int) Value {
+	switch s.kind {
+	case floatKind:
+		return Value(s.float / float64(count))
+	case signedKind:
+		return Value(s.signed / int64(count))
+	default:
+		return Value(s.unsigned / uint64(count))
+	}
+}
+
+// Min returns the smallest value.
+func Min[Value cmp.Ordered](values []Value) Value {
+	if len(values) == 0 {
+		var zero Value
+		return zero
+	}
+	return slices.Min(values)
+}
+
+// Max returns the largest value.
+func Max[Value cmp.Ordered](values []Value) Value {
+	if len(values) == 0 {
+		var zero Value
+		return zero
+	}
+	return slices.Max(values)
+}
+
+// CompoundReturn links periodic returns into the return over the whole span: (1+r₀)(1+r₁)…(1+rₙ) - 1.
+func CompoundReturn[Value Float](values []Value) Value {
+	growth := Value(1)
+	for _, value := range values {
+		growth *= 1 + value
+	}
+	return growth - 1
+}
+
+// Median returns the middle value, or the mean of the two middle values for an even number of values.
+// Integer medians are truncated.
+func Median[Value Number](values []Value) Value {
+	if len(values) == 0 {
+		return 0
+	}
+	sorted := slices.Clone(values)
+	slices.Sort(sorted)
+	middle := len(sorted) / 2
+	if len(sorted)%2 == 1 {
+		return sorted[middle]
+	}
+	return (sorted[middle-1] + sorted[middle]) / 2
+}
+
+// Variance returns the sample variance of the values. It is zero for fewer than two values.
+func Variance[Value Float](values []Value) Value {
+	if len(values) < 2 {
+		return 0
+	}
+	mean := Mean(values)
+	var sumOfSquares Value
+	for _, value := range values {
+		sumOfSquares += (value - mean) * (value - mean)
+	}
+	return sumOfSquares / Value(len(values)-1)
+}
+
+// Std returns the sample standard deviation of the values. It is zero for fewer than two values.
+func Std[Value Float](values []Value) Value {
+	return Value(math.Sqrt(float64(Variance(values))))
+}
+
+// MaxDrawdown returns the largest fall from a peak of the growth of periodic returns, as a negative fraction.
+// It is zero when the growth never falls below an earlier peak.
+func MaxDrawdown[Value Float](values []Value) Value {
+	var drawdown Value
+	growth, peak := Value(1), Value(1)
+	for _, value := range values {
+		growth *= 1 + value
+		peak = max(peak, growth)
+		drawdown = min(drawdown, growth/peak-1)
+	}
+	return drawdown
+}
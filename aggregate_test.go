@@ -0,0 +1,70 @@
+package timetable_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/portfoliotree/timetable"
+)
+
+func TestAggregation(t *testing.T) {
+	values := []Value{3, 1, 4, 1, 5}
+	for _, tt := range []struct {
+		Name      string
+		Aggregate timetable.Aggregation[Value]
+		Result    Value
+	}{
+		{Name: "first", Aggregate: timetable.First[Value], Result: 3},
+		{Name: "last", Aggregate: timetable.Last[Value], Result: 5},
+		{Name: "sum", Aggregate: timetable.Sum[Value], Result: 14},
+		{Name: "mean", Aggregate: timetable.Mean[Value], Result: 2},
+		{Name: "min", Aggregate: timetable.Min[Value], Result: 1},
+		{Name: "max", Aggregate: timetable.Max[Value], Result: 5},
+		{Name: "median", Aggregate: timetable.Median[Value], Result: 3},
+	} {
+		t.Run(tt.Name, func(t *testing.T) {
+			assert.Equal(t, tt.Result, tt.Aggregate(values))
+			assert.Zero(t, tt.Aggregate(nil))
+		})
+	}
+
+	t.Run("mean of many small integers", func(t *testing.T) {
+		unsigned := make([]uint8, 300)
+		signed := make([]int8, 300)
+		for i := range unsigned {
+			unsigned[i], signed[i] = 200, -100
+		}
+		unsigned[0] = 250
+		assert.Equal(t, uint8(200), timetable.Mean(unsigned))
+		assert.Equal(t, int8(-100), timetable.Mean(signed))
+
+		type price float32
+		assert.Equal(t, price(1.5), timetable.Mean([]price{1, 2}))
+	})
+
+	t.Run("compound return", func(t *testing.T) {
+		assert.InDelta(t, 0.21, timetable.CompoundReturn([]float64{0.1, 0.1}), 1e-12)
+		assert.Zero(t, timetable.CompoundReturn[float64](nil))
+	})
+
+	t.Run("median of an even number of values", func(t *testing.T) {
+		values := []float64{4, 1, 3, 2}
+		assert.Equal(t, 2.5, timetable.Median(values))
+		assert.Equal(t, []float64{4, 1, 3, 2}, values, "it does not sort the values in place")
+	})
+
+	t.Run("standard deviation", func(t *testing.T) {
+		assert.InDelta(t, 2.5, timetable.Variance([]float64{1, 2, 3, 4, 5}), 1e-12)
+		assert.InDelta(t, math.Sqrt(2.5), timetable.Std([]float64{1, 2, 3, 4, 5}), 1e-12)
+		assert.Zero(t, timetable.Std([]float64{1}))
+	})
+
+	t.Run("max drawdown", func(t *testing.T) {
+		assert.InDelta(t, -0.5, timetable.MaxDrawdown([]float64{0.2, -0.5, 0.5, 0.1}), 1e-12)
+		assert.InDelta(t, 0.99/1.1-1, timetable.MaxDrawdown([]float64{0.1, -0.1, 0.5}), 1e-12)
+		assert.Zero(t, timetable.MaxDrawdown([]float64{0.1, 0.2}))
+		assert.Zero(t, timetable.MaxDrawdown[float64](nil))
+	})
+}
@@ -0,0 +1,85 @@
+package timetable
+
+import (
+	"fmt"
+	"slices"
+	"time"
+)
+
+// AddColumns adds each list as a new column. The result has the same times and values as adding
+// the lists one at a time with AddColumnWithJoin, but the time index is computed once
+// and each column is filled in a single pass.
+func (table *Compact[Value]) AddColumns(lists []List[Value], missing func(time.Time, int) Value, join JoinMode) *Compact[Value] {
+	return table.addColumns(lists, make([]ColumnInfo, len(lists)), missing, join)
+}
+
+func (table *Compact[Value]) addColumns(lists []List[Value], infos []ColumnInfo, missing func(time.Time, int) Value, join JoinMode) *Compact[Value] {
+	updated, _ := table.joinColumns(lists, missing, join)
+	updated.columns = table.joinedColumnInfos(infos)
+	return updated
+}
+
+// joinedColumnInfos returns the column infos of the table followed by infos.
+// It returns nil when none of the columns carry a name or metadata.
+// Like joinColumns, it treats a table without times as having no columns.
+func (table *Compact[Value]) joinedColumnInfos(infos []ColumnInfo) []ColumnInfo {
+	var columns []ColumnInfo
+	if !table.isEmpty() {
+		columns = table.columns
+	}
+	if len(columns) == 0 && !slices.ContainsFunc(infos, func(info ColumnInfo) bool { return !info.isZero() }) {
+		return nil
+	}
+	if !table.isEmpty() {
+		columns = table.columnInfos()
+	}
+	return append(columns, infos...)
+}
+
+// Builder collects lists and builds a table from them in one pass.
+// Use it instead of calling AddColumn in a loop when loading many columns.
+type Builder[Value any] struct {
+	lists   []List[Value]
+	infos   []ColumnInfo
+	names   map[string]struct{}
+	missing func(time.Time, int) Value
+	join    JoinMode
+}
+
+// NewBuilder returns a Builder that joins its lists with join and fills gaps with missing.
+// When missing is nil, gaps are marked missing.
+func NewBuilder[Value any](missing func(time.Time, int) Value, join JoinMode) *Builder[Value] {
+	return &Builder[Value]{missing: missing, join: join, names: make(map[string]struct{})}
+}
+
+// Add appends an unnamed column. The list is not copied, so it must not be changed before Build is called.
+func (builder *Builder[Value]) Add(list List[Value]) {
+	builder.lists = append(builder.lists, list)
+	builder.infos = append(builder.infos, ColumnInfo{})
+}
+
+// AddNamed appends a named column. It returns an error if the name was already added.
+func (builder *Builder[Value]) AddNamed(name string, list List[Value]) error {
+	return builder.AddWithInfo(ColumnInfo{Name: name}, list)
+}
+
+// AddWithInfo appends a column with a name and metadata. It returns an error if the name was already added.
+func (builder *Builder[Value]) AddWithInfo(info ColumnInfo, list List[Value]) error {
+	if info.Name != "" {
+		if _, found := builder.names[info.Name]; found {
+			return fmt.Errorf("duplicate column name %q", info.Name)
+		}
+		builder.names[info.Name] = struct{}{}
+	}
+	builder.lists = append(builder.lists, list)
+	builder.infos = append(builder.infos, info.clone())
+	return nil
+}
+
+// Len returns the number of columns added so far.
+func (builder *Builder[Value]) Len() int { return len(builder.lists) }
+
+// Build returns a new table holding every column added so far.
+func (builder *Builder[Value]) Build() *Compact[Value] {
+	return new(Compact[Value]).addColumns(builder.lists, builder.infos, builder.missing, builder.join)
+}
@@ -0,0 +1,124 @@
+package timetable_test
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/portfoliotree/timetable"
+)
+
+func randomLists(rng *rand.Rand, columns, days int) []List {
+	lists := make([]List, columns)
+	for column := range lists {
+		start, end := rng.IntN(days), rng.IntN(days)
+		if end < start {
+			start, end = end, start
+		}
+		var list List
+		for day := start; day <= end; day++ {
+			if rng.IntN(4) == 0 {
+				continue
+			}
+			list = append(list, timetable.NewCell(date(day0).AddDate(0, 0, day), column*1000+day))
+		}
+		rng.Shuffle(len(list), func(i, j int) { list[i], list[j] = list[j], list[i] })
+		lists[column] = list
+	}
+	return lists
+}
+
+func TestCompact_AddColumns(t *testing.T) {
+	rng := rand.New(rand.NewPCG(1, 2))
+	for _, join := range []timetable.JoinMode{
+		timetable.JoinOverlap,
+		timetable.JoinInner,
+		timetable.JoinOuter,
+		timetable.JoinLeft,
+		timetable.JoinRight,
+	} {
+		t.Run(join.String(), func(t *testing.T) {
+			for i := range 200 {
+				lists := randomLists(rng, 1+rng.IntN(6), 1+rng.IntN(20))
+				var base *Table
+				if i%2 == 0 {
+					base = timetable.New(lists[0])
+					lists = lists[1:]
+				}
+
+				expected := base
+				for _, list := range lists {
+					expected = expected.AddColumnWithJoin(list, missingNegativeOne, join)
+				}
+				result := base.AddColumns(lists, missingNegativeOne, join)
+
+				if expected == nil {
+					assert.Equal(t, 0, result.NumberOfColumns())
+					continue
+				}
+				require.Equal(t, expected.Times(), result.Times(), "it keeps the same times as adding one column at a time")
+				require.Equal(t, expected.Values(), result.Values(), "it keeps the same values as adding one column at a time")
+
+				masked := base
+				for _, list := range lists {
+					masked = masked.AddColumnWithJoin(list, nil, join)
+				}
+				require.Equal(t, missingCells(masked), missingCells(base.AddColumns(lists, nil, join)), "it marks the same cells missing as adding one column at a time")
+			}
+		})
+	}
+
+	t.Run("names", func(t *testing.T) {
+		table, err := timetable.NewNamed([]string{"AAA"}, List{elV(day0, 1)})
+		require.NoError(t, err)
+		result := table.AddColumns([]List{{elV(day0, 2)}, {elV(day0, 3)}}, nil, timetable.JoinOuter)
+		assert.Equal(t, []string{"AAA", "", ""}, result.ColumnNames())
+		assert.Equal(t, [][]Value{{1}, {2}, {3}}, result.Values())
+	})
+}
+
+func TestBuilder(t *testing.T) {
+	builder := timetable.NewBuilder[Value](missingNegativeOne, timetable.JoinOuter)
+	require.NoError(t, builder.AddNamed("AAA", List{elV(day1, 2), elV(day0, 1)}))
+	builder.Add(List{elV(day2, 30)})
+	require.NoError(t, builder.AddWithInfo(timetable.ColumnInfo{
+		Name:     "CCC",
+		Metadata: map[string]string{"currency": "USD"},
+	}, List{elV(day1, 200)}))
+	assert.Error(t, builder.AddNamed("AAA", List{}))
+	assert.Equal(t, 3, builder.Len())
+
+	table := builder.Build()
+	assert.Equal(t, []string{"AAA", "", "CCC"}, table.ColumnNames())
+	assert.Equal(t, [][]Value{
+		{1, 2, -1},
+		{-1, -1, 30},
+		{-1, 200, -1},
+	}, table.Values())
+	info, _ := table.ColumnInfo(2)
+	assert.Equal(t, "USD", info.Metadata["currency"])
+
+	t.Run("empty", func(t *testing.T) {
+		table := timetable.NewBuilder[Value](nil, timetable.JoinOverlap).Build()
+		assert.Equal(t, 0, table.NumberOfColumns())
+		assert.Empty(t, table.ColumnNames())
+	})
+}
+
+func BenchmarkBuilder(b *testing.B) {
+	for _, columns := range []int{500, 5000} {
+		lists := benchmarkLists(columns, 2500)
+		b.Run(fmt.Sprintf("%d columns", columns), func(b *testing.B) {
+			for range b.N {
+				builder := timetable.NewBuilder[Value](nil, timetable.JoinOuter)
+				for _, list := range lists {
+					builder.Add(list)
+				}
+				_ = builder.Build()
+			}
+		})
+	}
+}
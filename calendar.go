@@ -0,0 +1,308 @@
+package timetable
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Calendar knows which days are trading days.
+// A day is a trading day when it is neither a weekend day nor a holiday.
+// Days are compared by their date in the location of the time being checked.
+type Calendar struct {
+	weekend  [7]bool
+	holidays map[civilDate]struct{}
+}
+
+type civilDate struct {
+	year  int
+	month time.Month
+	day   int
+}
+
+func dateOf(t time.Time) civilDate {
+	year, month, day := t.Date()
+	return civilDate{year: year, month: month, day: day}
+}
+
+// NewCalendar returns a calendar with the given weekend days and holidays.
+// It returns an error if a weekend day is not a valid weekday or the weekend covers every day of the week.
+func NewCalendar(weekend []time.Weekday, holidays ...time.Time) (*Calendar, error) {
+	calendar := &Calendar{holidays: make(map[civilDate]struct{}, len(holidays))}
+	for _, day := range weekend {
+		if day < time.Sunday || day > time.Saturday {
+			return nil, fmt.Errorf("weekend day %d is not a weekday", day)
+		}
+		calendar.weekend[day] = true
+	}
+	if !slices.Contains(calendar.weekend[:], false) {
+		return nil, errors.New("weekend covers every day of the week")
+	}
+	for _, holiday := range holidays {
+		calendar.holidays[dateOf(holiday)] = struct{}{}
+	}
+	return calendar, nil
+}
+
+// WeekdayCalendar returns a calendar where every Monday through Friday is a trading day.
+func WeekdayCalendar() *Calendar {
+	calendar := &Calendar{holidays: make(map[civilDate]struct{})}
+	calendar.weekend[time.Saturday], calendar.weekend[time.Sunday] = true, true
+	return calendar
+}
+
+// IsTradingDay reports whether the date of t is a trading day.
+func (calendar *Calendar) IsTradingDay(t time.Time) bool {
+	if calendar.weekend[t.Weekday()] {
+		return false
+	}
+	_, isHoliday := calendar.holidays[dateOf(t)]
+	return !isHoliday
+}
+
+// Next returns midnight of the first trading day after the date of t.
+func (calendar *Calendar) Next(t time.Time) time.Time {
+	year, month, day := t.Date()
+	next := time.Date(year, month, day+1, 0, 0, 0, 0, t.Location())
+	// NewCalendar ensures every week has a trading weekday, and there are finitely many holidays, so the loop ends.
+	for !calendar.IsTradingDay(next) {
+		next = next.AddDate(0, 0, 1)
+	}
+	return next
+}
+
+// TradingDays returns midnight of each trading day from t0 to t1 inclusive, in the location of t0.
+func (calendar *Calendar) TradingDays(t0, t1 time.Time) []time.Time {
+	if t1.Before(t0) {
+		t0, t1 = t1, t0
+	}
+	year, month, day := t0.Date()
+	var days []time.Time
+	for t := time.Date(year, month, day, 0, 0, 0, 0, t0.Location()); !t.After(t1); t = t.AddDate(0, 0, 1) {
+		if calendar.IsTradingDay(t) {
+			days = append(days, t)
+		}
+	}
+	return days
+}
+
+// PeriodStart returns midnight of the date of t, so each day is a period of the calendar.
+func (calendar *Calendar) PeriodStart(t time.Time) time.Time {
+	year, month, day := t.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, t.Location())
+}
+
+// Periods is the same as TradingDays. Together with PeriodStart it makes a Calendar a Schedule.
+func (calendar *Calendar) Periods(t0, t1 time.Time) []time.Time {
+	return calendar.TradingDays(t0, t1)
+}
+
+// Holidays returns the holidays in the calendar in order, as midnight UTC.
+func (calendar *Calendar) Holidays() []time.Time {
+	holidays := make([]time.Time, 0, len(calendar.holidays))
+	for d := range calendar.holidays {
+		holidays = append(holidays, time.Date(d.year, d.month, d.day, 0, 0, 0, 0, time.UTC))
+	}
+	slices.SortFunc(holidays, time.Time.Compare)
+	return holidays
+}
+
+// Weekend returns the weekend days in the calendar.
+func (calendar *Calendar) Weekend() []time.Weekday {
+	var days []time.Weekday
+	for day, isWeekend := range calendar.weekend {
+		if isWeekend {
+			days = append(days, time.Weekday(day))
+		}
+	}
+	return days
+}
+
+// FilterTradingDays returns the rows of the table that fall on trading days.
+func (table *Compact[Value]) FilterTradingDays(calendar *Calendar) *Compact[Value] {
+	times := make([]time.Time, 0, len(table.times))
+	values := make([][]Value, len(table.values))
+	for column := range values {
+		values[column] = make([]Value, 0, len(table.times))
+	}
+	var rows []int
+	for row, t := range table.times {
+		if !calendar.IsTradingDay(t) {
+			continue
+		}
+		times = append(times, t)
+		rows = append(rows, row)
+		for column := range values {
+			values[column] = append(values[column], table.values[column][row])
+		}
+	}
+	return &Compact[Value]{times: times, values: values, columns: slices.Clone(table.columns), missing: selectMask(table.missing, rows)}
+}
+
+// FilterTradingDays returns the cells of the list that fall on trading days.
+func (list List[Value]) FilterTradingDays(calendar *Calendar) List[Value] {
+	result := make(List[Value], 0, len(list))
+	for _, cell := range list {
+		if calendar.IsTradingDay(cell.time) {
+			result = append(result, cell)
+		}
+	}
+	return result
+}
+
+// MissingTradingDays returns the trading days between the first and last cell of the list
+// that have no cell. The list is sorted in place first.
+func (list List[Value]) MissingTradingDays(calendar *Calendar) []time.Time {
+	if len(list) == 0 {
+		return nil
+	}
+	slices.SortFunc(list, Cell[Value].compareTimes)
+	loc := list[0].time.Location()
+	observed := make(map[civilDate]struct{}, len(list))
+	for _, cell := range list {
+		observed[dateOf(cell.time.In(loc))] = struct{}{}
+	}
+	var missing []time.Time
+	for _, day := range calendar.TradingDays(list.FirstTime(), list.LastTime()) {
+		if _, found := observed[dateOf(day)]; !found {
+			missing = append(missing, day)
+		}
+	}
+	return missing
+}
+
+// ParseCalendar reads a calendar from a text format.
+// Each line is blank, a comment starting with "#", a line starting with "weekend"
+// followed by day names, or a holiday date in the form 2006-01-02 optionally followed by a description.
+// The weekend defaults to Saturday and Sunday when no weekend line is given.
+//
+//	# NYSE
+//	weekend Saturday Sunday
+//	2022-12-26 Christmas Day (observed)
+func ParseCalendar(r io.Reader) (*Calendar, error) {
+	var (
+		weekend     = []time.Weekday{time.Saturday, time.Sunday}
+		weekendLine int
+		holidays    []time.Time
+	)
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+		if strings.EqualFold(fields[0], "weekend") {
+			days, err := parseWeekdays(fields[1:])
+			if err != nil {
+				return nil, fmt.Errorf("calendar line %d: %w", line, err)
+			}
+			weekend, weekendLine = days, line
+			continue
+		}
+		holiday, err := time.Parse(time.DateOnly, fields[0])
+		if err != nil {
+			return nil, fmt.Errorf("calendar line %d: %w", line, err)
+		}
+		holidays = append(holidays, holiday)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	calendar, err := NewCalendar(weekend, holidays...)
+	if err != nil {
+		return nil, fmt.Errorf("calendar line %d: %w", weekendLine, err)
+	}
+	return calendar, nil
+}
+
+// LoadCalendar reads a calendar from a file.
+// Files with a ".json" extension are decoded with UnmarshalJSON, all others with ParseCalendar.
+func LoadCalendar(filename string) (*Calendar, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = f.Close() }()
+	if strings.EqualFold(filepath.Ext(filename), ".json") {
+		calendar := new(Calendar)
+		if err := json.NewDecoder(f).Decode(calendar); err != nil {
+			return nil, fmt.Errorf("failed to decode calendar %s: %w", filename, err)
+		}
+		return calendar, nil
+	}
+	return ParseCalendar(f)
+}
+
+type calendarJSON struct {
+	Weekend  *[]string `json:"weekend,omitempty"`
+	Holidays []string  `json:"holidays"`
+}
+
+// MarshalJSON encodes the calendar as {"weekend": ["Saturday", "Sunday"], "holidays": ["2022-12-26"]}.
+func (calendar *Calendar) MarshalJSON() ([]byte, error) {
+	weekend := make([]string, 0, 2)
+	for _, day := range calendar.Weekend() {
+		weekend = append(weekend, day.String())
+	}
+	holidays := make([]string, 0, len(calendar.holidays))
+	for _, holiday := range calendar.Holidays() {
+		holidays = append(holidays, holiday.Format(time.DateOnly))
+	}
+	return json.Marshal(calendarJSON{Weekend: &weekend, Holidays: holidays})
+}
+
+// UnmarshalJSON decodes the format written by MarshalJSON.
+// The weekend defaults to Saturday and Sunday when the field is absent.
+func (calendar *Calendar) UnmarshalJSON(data []byte) error {
+	var decoded calendarJSON
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+	weekend := []time.Weekday{time.Saturday, time.Sunday}
+	if decoded.Weekend != nil {
+		days, err := parseWeekdays(*decoded.Weekend)
+		if err != nil {
+			return err
+		}
+		weekend = days
+	}
+	holidays := make([]time.Time, 0, len(decoded.Holidays))
+	for _, value := range decoded.Holidays {
+		holiday, err := time.Parse(time.DateOnly, value)
+		if err != nil {
+			return err
+		}
+		holidays = append(holidays, holiday)
+	}
+	decodedCalendar, err := NewCalendar(weekend, holidays...)
+	if err != nil {
+		return err
+	}
+	*calendar = *decodedCalendar
+	return nil
+}
+
+func parseWeekdays(names []string) ([]time.Weekday, error) {
+	days := make([]time.Weekday, 0, len(names))
+	for _, name := range names {
+		index := slices.IndexFunc(weekdays[:], func(day time.Weekday) bool {
+			return strings.EqualFold(day.String(), name) || strings.EqualFold(day.String()[:3], name)
+		})
+		if index < 0 {
+			return nil, fmt.Errorf("unknown weekday %q", name)
+		}
+		days = append(days, weekdays[index])
+	}
+	return days, nil
+}
+
+var weekdays = [...]time.Weekday{
+	time.Sunday, time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday, time.Saturday,
+}
@@ -0,0 +1,136 @@
+package timetable_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/portfoliotree/timetable"
+)
+
+const christmasObserved = "2022-12-26" // Monday
+
+func TestCalendar_IsTradingDay(t *testing.T) {
+	calendar, err := timetable.NewCalendar([]time.Weekday{time.Saturday, time.Sunday}, date(christmasObserved))
+	require.NoError(t, err)
+
+	assert.True(t, calendar.IsTradingDay(date(day0)))
+	assert.False(t, calendar.IsTradingDay(date("2022-10-22")), "Saturday")
+	assert.False(t, calendar.IsTradingDay(date("2022-10-23")), "Sunday")
+	assert.False(t, calendar.IsTradingDay(date(christmasObserved).Add(15*time.Hour)), "holiday with a time of day")
+
+	assert.Equal(t, date(day2), calendar.Next(date(day1)))
+	assert.Equal(t, date("2022-12-27"), calendar.Next(date("2022-12-23")))
+
+	assert.Equal(t, []time.Time{date(day1), date(day2), date(day3)}, calendar.TradingDays(date(day1), date(day3)))
+	assert.Equal(t, []time.Time{date(day1), date(day2)}, calendar.TradingDays(date(day2), date(day1)))
+
+	t.Run("one trading weekday", func(t *testing.T) {
+		weekend := []time.Weekday{time.Tuesday, time.Wednesday, time.Thursday, time.Friday, time.Saturday, time.Sunday}
+		calendar, err := timetable.NewCalendar(weekend, date("2022-10-24"), date("2022-10-31"))
+		require.NoError(t, err)
+		assert.Equal(t, date("2022-11-07"), calendar.Next(date("2022-10-23")))
+		assert.Equal(t, date("2022-10-17"), calendar.Next(date("2022-10-16")))
+	})
+}
+
+func TestNewCalendar(t *testing.T) {
+	_, err := timetable.NewCalendar([]time.Weekday{time.Saturday, 7})
+	assert.Error(t, err)
+	_, err = timetable.NewCalendar([]time.Weekday{-1})
+	assert.Error(t, err)
+
+	every := []time.Weekday{time.Sunday, time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday, time.Saturday}
+	_, err = timetable.NewCalendar(every)
+	assert.Error(t, err)
+
+	assert.Equal(t, []time.Weekday{time.Sunday, time.Saturday}, timetable.WeekdayCalendar().Weekend())
+}
+
+func TestCompact_FilterTradingDays(t *testing.T) {
+	table := timetable.New(List{elV(day1, 1), elV("2022-10-22", 2), elV(day2, 3)})
+	result := table.FilterTradingDays(timetable.WeekdayCalendar())
+	assert.Equal(t, []time.Time{date(day1), date(day2)}, result.Times())
+	assert.Equal(t, [][]Value{{1, 3}}, result.Values())
+
+	list := List{elV(day1, 1), elV("2022-10-22", 2), elV(day2, 3)}
+	assert.Equal(t, List{elV(day1, 1), elV(day2, 3)}, list.FilterTradingDays(timetable.WeekdayCalendar()))
+}
+
+func TestList_MissingTradingDays(t *testing.T) {
+	list := List{elV(day3, 1), elV(day0, 1)}
+	assert.Equal(t, []time.Time{date(day1), date(day2)}, list.MissingTradingDays(timetable.WeekdayCalendar()))
+	assert.Nil(t, List(nil).MissingTradingDays(timetable.WeekdayCalendar()))
+}
+
+func TestParseCalendar(t *testing.T) {
+	t.Run("text", func(t *testing.T) {
+		calendar, err := timetable.ParseCalendar(strings.NewReader(`# example
+weekend Fri Sat
+
+2022-12-26 Christmas Day (observed)
+`))
+		require.NoError(t, err)
+		assert.Equal(t, []time.Weekday{time.Friday, time.Saturday}, calendar.Weekend())
+		assert.Equal(t, []time.Time{date(christmasObserved)}, calendar.Holidays())
+	})
+
+	t.Run("default weekend", func(t *testing.T) {
+		calendar, err := timetable.ParseCalendar(strings.NewReader("2022-12-26\n"))
+		require.NoError(t, err)
+		assert.Equal(t, []time.Weekday{time.Sunday, time.Saturday}, calendar.Weekend())
+	})
+
+	t.Run("bad date", func(t *testing.T) {
+		_, err := timetable.ParseCalendar(strings.NewReader("weekend Saturday\n12/26/2022\n"))
+		assert.ErrorContains(t, err, "line 2")
+	})
+
+	t.Run("no trading weekday", func(t *testing.T) {
+		_, err := timetable.ParseCalendar(strings.NewReader("weekend Sun Mon Tue Wed Thu Fri Sat\n"))
+		assert.ErrorContains(t, err, "line 1")
+	})
+
+	t.Run("bad weekday", func(t *testing.T) {
+		_, err := timetable.ParseCalendar(strings.NewReader("weekend Caturday\n"))
+		assert.ErrorContains(t, err, "Caturday")
+	})
+}
+
+func TestCalendar_JSON(t *testing.T) {
+	calendar, err := timetable.NewCalendar([]time.Weekday{time.Saturday, time.Sunday}, date(christmasObserved))
+	require.NoError(t, err)
+	buf, err := json.Marshal(calendar)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"weekend":["Sunday","Saturday"],"holidays":["2022-12-26"]}`, string(buf))
+
+	var decoded timetable.Calendar
+	require.NoError(t, json.Unmarshal([]byte(`{"holidays":["2022-12-26"]}`), &decoded))
+	assert.Equal(t, calendar, &decoded)
+
+	require.NoError(t, json.Unmarshal([]byte(`{"weekend":[]}`), &decoded))
+	assert.True(t, decoded.IsTradingDay(date("2022-10-22")))
+
+	assert.Error(t, json.Unmarshal([]byte(`{"weekend":["Sun","Mon","Tue","Wed","Thu","Fri","Sat"]}`), &decoded))
+}
+
+func TestLoadCalendar(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "nyse.json"), []byte(`{"holidays":["2022-12-26"]}`), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "nyse.txt"), []byte("2022-12-26\n"), 0o600))
+
+	fromJSON, err := timetable.LoadCalendar(filepath.Join(dir, "nyse.json"))
+	require.NoError(t, err)
+	fromText, err := timetable.LoadCalendar(filepath.Join(dir, "nyse.txt"))
+	require.NoError(t, err)
+	assert.Equal(t, fromJSON, fromText)
+
+	_, err = timetable.LoadCalendar(filepath.Join(dir, "missing.txt"))
+	assert.Error(t, err)
+}
@@ -6,20 +6,31 @@ import (
 )
 
 type Cell[Value any] struct {
-	time  time.Time
-	value Value
+	time    time.Time
+	value   Value
+	missing bool
 }
 
 func NewCell[Value any](time time.Time, value Value) Cell[Value] {
 	return Cell[Value]{time: time, value: value}
 }
 
+// NewMissingCell returns a cell at t that has no value.
+// Adding it to a table marks the cell at t as missing instead of storing a value.
+func NewMissingCell[Value any](t time.Time) Cell[Value] {
+	return Cell[Value]{time: t, missing: true}
+}
+
 func (c Cell[Value]) compareTimes(o Cell[Value]) int { return c.time.Compare(o.time) }
+func (c Cell[Value]) compareTime(t time.Time) int    { return c.time.Compare(t) }
 
 func (c Cell[Value]) Time() time.Time { return c.time }
 
 func (c Cell[Value]) Value() Value { return c.value }
 
+// IsMissing reports whether the cell has no value, in which case Value should be ignored.
+func (c Cell[Value]) IsMissing() bool { return c.missing }
+
 func (c Cell[Value]) GoString() string {
 	return fmt.Sprintf("%T{time: %q, value: %#v}", c, c.time, c.value)
 }
@@ -0,0 +1,141 @@
+package timetable
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+)
+
+// ColumnInfo describes a column of a Compact table.
+// An empty Name means the column is unnamed; unnamed columns are only addressable by index.
+type ColumnInfo struct {
+	Name     string            `json:"name,omitempty"`
+	Metadata map[string]string `json:"metadata,omitempty"`
+}
+
+func (info ColumnInfo) clone() ColumnInfo {
+	return ColumnInfo{Name: info.Name, Metadata: maps.Clone(info.Metadata)}
+}
+
+func (info ColumnInfo) isZero() bool { return info.Name == "" && len(info.Metadata) == 0 }
+
+// NewNamed is like New but assigns a name to each column.
+// It returns an error if the number of names does not match the number of columns or a name is repeated.
+func NewNamed[Value any](names []string, columns ...List[Value]) (*Compact[Value], error) {
+	if len(names) != len(columns) {
+		return nil, fmt.Errorf("got %d column names for %d columns", len(names), len(columns))
+	}
+	table := New(columns...)
+	if err := table.SetColumnNames(names...); err != nil {
+		return nil, err
+	}
+	return table, nil
+}
+
+// AddNamedColumn is like AddColumn but names the new column.
+func (table *Compact[Value]) AddNamedColumn(name string, list List[Value], missing func(time.Time, int) Value) (*Compact[Value], error) {
+	if _, found := table.ColumnIndex(name); found {
+		return nil, fmt.Errorf("duplicate column name %q", name)
+	}
+	return table.addColumn(list, missing, JoinOverlap, ColumnInfo{Name: name}), nil
+}
+
+// ColumnNames returns the name of each column. Unnamed columns have an empty name.
+func (table *Compact[Value]) ColumnNames() []string {
+	if table == nil {
+		return nil
+	}
+	names := make([]string, len(table.values))
+	for i := range names {
+		names[i] = table.columnInfo(i).Name
+	}
+	return names
+}
+
+// SetColumnNames replaces the names of all columns.
+// It returns an error if the number of names does not match the number of columns or a name is repeated.
+func (table *Compact[Value]) SetColumnNames(names ...string) error {
+	if len(names) != len(table.values) {
+		return fmt.Errorf("got %d column names for %d columns", len(names), len(table.values))
+	}
+	if err := checkDuplicateNames(names); err != nil {
+		return err
+	}
+	columns := table.columnInfos()
+	for i, name := range names {
+		columns[i].Name = name
+	}
+	table.columns = columns
+	return nil
+}
+
+// ColumnInfo returns the name and metadata of a column.
+// The returned metadata is a copy and may be modified freely.
+func (table *Compact[Value]) ColumnInfo(column int) (ColumnInfo, bool) {
+	if table == nil || column < 0 || column >= len(table.values) {
+		return ColumnInfo{}, false
+	}
+	return table.columnInfo(column).clone(), true
+}
+
+// SetColumnInfo replaces the name and metadata of a column.
+func (table *Compact[Value]) SetColumnInfo(column int, info ColumnInfo) error {
+	if column < 0 || column >= len(table.values) {
+		return fmt.Errorf("column %d out of range", column)
+	}
+	if index, found := table.ColumnIndex(info.Name); found && index != column {
+		return fmt.Errorf("duplicate column name %q", info.Name)
+	}
+	columns := table.columnInfos()
+	columns[column] = info.clone()
+	table.columns = columns
+	return nil
+}
+
+// ColumnIndex returns the index of the column with the given name.
+func (table *Compact[Value]) ColumnIndex(name string) (int, bool) {
+	if table == nil || name == "" {
+		return -1, false
+	}
+	index := slices.IndexFunc(table.columns, func(info ColumnInfo) bool { return info.Name == name })
+	return index, index >= 0
+}
+
+// ColumnByName is like Column but looks up the column by name.
+func (table *Compact[Value]) ColumnByName(name string) (List[Value], bool) {
+	index, found := table.ColumnIndex(name)
+	if !found {
+		var zero List[Value]
+		return zero[:], false
+	}
+	return table.Column(index)
+}
+
+func (table *Compact[Value]) columnInfo(column int) ColumnInfo {
+	if column < len(table.columns) {
+		return table.columns[column]
+	}
+	return ColumnInfo{}
+}
+
+// columnInfos returns a new slice with one entry per column.
+func (table *Compact[Value]) columnInfos() []ColumnInfo {
+	columns := make([]ColumnInfo, len(table.values))
+	copy(columns, table.columns)
+	return columns
+}
+
+func checkDuplicateNames(names []string) error {
+	seen := make(map[string]struct{}, len(names))
+	for _, name := range names {
+		if name == "" {
+			continue
+		}
+		if _, found := seen[name]; found {
+			return fmt.Errorf("duplicate column name %q", name)
+		}
+		seen[name] = struct{}{}
+	}
+	return nil
+}
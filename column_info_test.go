@@ -0,0 +1,99 @@
+package timetable_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/portfoliotree/timetable"
+)
+
+func TestNewNamed(t *testing.T) {
+	t.Run("names each column", func(t *testing.T) {
+		table, err := timetable.NewNamed([]string{"AAA", "BBB"},
+			List{elV(day0, 1), elV(day1, 2)},
+			List{elV(day0, 10), elV(day1, 20)},
+		)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"AAA", "BBB"}, table.ColumnNames())
+		if column, ok := table.ColumnByName("BBB"); assert.True(t, ok) {
+			assert.Equal(t, List{elV(day0, 10), elV(day1, 20)}, column)
+		}
+	})
+
+	t.Run("duplicate names", func(t *testing.T) {
+		_, err := timetable.NewNamed([]string{"AAA", "AAA"}, List{elV(day0, 1)}, List{elV(day0, 2)})
+		assert.ErrorContains(t, err, `duplicate column name "AAA"`)
+	})
+
+	t.Run("wrong number of names", func(t *testing.T) {
+		_, err := timetable.NewNamed([]string{"AAA"}, List{elV(day0, 1)}, List{elV(day0, 2)})
+		assert.Error(t, err)
+	})
+}
+
+func TestCompact_AddNamedColumn(t *testing.T) {
+	table, err := timetable.New(List{elV(day0, 1), elV(day1, 2)}).
+		AddNamedColumn("BBB", List{elV(day0, 10), elV(day1, 20)}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"", "BBB"}, table.ColumnNames())
+
+	index, ok := table.ColumnIndex("BBB")
+	assert.True(t, ok)
+	assert.Equal(t, 1, index)
+
+	_, err = table.AddNamedColumn("BBB", List{elV(day0, 100)}, nil)
+	assert.Error(t, err)
+
+	t.Run("names are kept by AddColumn", func(t *testing.T) {
+		updated := table.AddColumnFillMissingWithZero(List{elV(day0, 100), elV(day1, 200)})
+		assert.Equal(t, []string{"", "BBB", ""}, updated.ColumnNames())
+		assert.Equal(t, []string{"", "BBB"}, table.ColumnNames())
+	})
+
+	t.Run("names are kept by Between", func(t *testing.T) {
+		updated := table.Between(date(day1), date(day1))
+		assert.Equal(t, []string{"", "BBB"}, updated.ColumnNames())
+		if column, ok := updated.ColumnByName("BBB"); assert.True(t, ok) {
+			assert.Equal(t, List{elV(day1, 20)}, column)
+		}
+	})
+
+	t.Run("names are kept when nothing overlaps", func(t *testing.T) {
+		updated := table.AddColumnFillMissingWithZero(List{elV(dayAfter, 1)})
+		assert.Equal(t, []string{"", "BBB", ""}, updated.ColumnNames())
+	})
+}
+
+func TestCompact_ColumnInfo(t *testing.T) {
+	table := timetable.New(List{elV(day0, 1)}, List{elV(day0, 2)})
+
+	err := table.SetColumnInfo(1, timetable.ColumnInfo{
+		Name:     "BBB",
+		Metadata: map[string]string{"currency": "USD"},
+	})
+	require.NoError(t, err)
+
+	info, ok := table.ColumnInfo(1)
+	assert.True(t, ok)
+	assert.Equal(t, "BBB", info.Name)
+	assert.Equal(t, "USD", info.Metadata["currency"])
+
+	info.Metadata["currency"] = "EUR"
+	info, _ = table.ColumnInfo(1)
+	assert.Equal(t, "USD", info.Metadata["currency"], "it returns a copy of the metadata")
+
+	assert.Error(t, table.SetColumnInfo(0, timetable.ColumnInfo{Name: "BBB"}))
+	assert.Error(t, table.SetColumnInfo(2, timetable.ColumnInfo{Name: "CCC"}))
+
+	_, ok = table.ColumnInfo(2)
+	assert.False(t, ok)
+	_, ok = table.ColumnByName("CCC")
+	assert.False(t, ok)
+
+	assert.NoError(t, table.SetColumnNames("AAA", "BBB"))
+	info, _ = table.ColumnInfo(1)
+	assert.Equal(t, "USD", info.Metadata["currency"], "renaming keeps metadata")
+	assert.Error(t, table.SetColumnNames("AAA"))
+}
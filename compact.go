@@ -6,16 +6,17 @@ import (
 )
 
 type Compact[Value any] struct {
-	times  []time.Time
-	values [][]Value
+	times   []time.Time
+	values  [][]Value
+	columns []ColumnInfo
+
+	// missing is nil when no cell is missing. Otherwise it has one entry per column
+	// that is nil when no cell in the column is missing.
+	missing [][]bool
 }
 
 func New[Value any](columns ...List[Value]) *Compact[Value] {
-	table := new(Compact[Value])
-	for _, column := range columns {
-		table = table.AddColumnFillMissingWithZero(column)
-	}
-	return table
+	return new(Compact[Value]).AddColumns(columns, zeroValue[Value], JoinOverlap)
 }
 
 func (table *Compact[Value]) Times() []time.Time { return slices.Clone(table.times) }
@@ -43,8 +44,7 @@ func (table *Compact[Value]) Column(column int) (List[Value], bool) {
 	}
 	list := make(List[Value], len(table.times))
 	for row := range table.times {
-		list[row].time = table.times[row]
-		list[row].value = table.values[column][row]
+		list[row] = table.cell(row, column)
 	}
 	return list, true
 }
@@ -55,11 +55,7 @@ func (table *Compact[Value]) Row(t time.Time) ([]Value, bool) {
 		var zero [0]Value
 		return zero[:], false
 	}
-	list := make([]Value, len(table.values))
-	for column := range table.values {
-		list[column] = table.values[column][index]
-	}
-	return list, true
+	return table.row(index), true
 }
 
 func (table *Compact[Value]) AddColumnFillMissingWithZero(list List[Value]) *Compact[Value] {
@@ -71,135 +67,43 @@ func zeroValue[Value any](time.Time, int) Value {
 	return zero
 }
 
-func zeroTable[Value any](n int) *Compact[Value] {
-	values := make([][]Value, n)
-	var zeroValues [0]Value
-	for i := range values {
-		values[i] = zeroValues[:0:0]
-	}
-	var zeroTimes [0]time.Time
-	return &Compact[Value]{
-		times:  zeroTimes[:0:0],
-		values: values,
-	}
-}
-
+// AddColumn adds list as a new column using JoinOverlap.
+// The missing function is called for each cell that neither the table nor the list has a value for.
 func (table *Compact[Value]) AddColumn(list List[Value], missing func(time.Time, int) Value) *Compact[Value] {
-	if table.isEmpty() {
-		return addInitialColumn(list)
-	}
-	if table.NumberOfRows() == 0 {
-		return zeroTable[Value](len(table.values) + 1)
-	}
-	list = slices.Clone(list)
-	slices.SortFunc(list, Cell[Value].compareTimes)
-	t0, t1 := table.FirstTime(), table.LastTime()
-	list = list.Between(t0, t1)
-	if len(list) == 0 {
-		return zeroTable[Value](len(table.values) + 1)
-	}
-	t0, t1 = list.FirstTime(), list.LastTime()
-	updated := table.Between(t0, t1)
-	if updated.NumberOfRows() == 0 {
-		return zeroTable[Value](len(table.values) + 1)
-	}
-	return updated.addAdditionalColumn(list, missing)
+	return table.AddColumnWithJoin(list, missing, JoinOverlap)
 }
 
-func addInitialColumn[Value any](list List[Value]) *Compact[Value] {
-	table := new(Compact[Value])
-	slices.SortFunc(list, Cell[Value].compareTimes)
-	newValues := make([]Value, 0, len(list))
-	table.times = make([]time.Time, 0, len(list))
-	for _, element := range list {
-		table.times = append(table.times, element.time)
-		newValues = append(newValues, element.value)
-	}
-	table.values = append(table.values, newValues)
-	return table
-}
-
-func (table *Compact[Value]) addAdditionalColumn(list List[Value], missing func(time.Time, int) Value) *Compact[Value] {
-	var missingTimes []time.Time
-	for _, cell := range list {
-		_, found := slices.BinarySearchFunc(table.times, cell.time, time.Time.Compare)
-		if !found {
-			missingTimes = append(missingTimes, cell.time)
-		}
-	}
-	times := slices.Grow(missingTimes, len(table.times)+len(missingTimes))
-	times = append(times, table.times...)
-	slices.SortFunc(times, time.Time.Compare)
-	times = slices.Compact(times)
-	values := make([][]Value, len(table.values)+1)
-
-	for column := range table.values {
-		values[column] = slices.Grow(table.values[column], len(times))
-		values[column] = values[column][:0]
-		for _, t := range times {
-			index, found := slices.BinarySearchFunc(table.times, t, time.Time.Compare)
-			var value Value
-			if found {
-				value = table.values[column][index]
-			} else {
-				value = missing(t, column)
-			}
-			values[column] = append(values[column], value)
-		}
-	}
-	for _, t := range times {
-		index, found := slices.BinarySearchFunc(list, Cell[Value]{time: t}, Cell[Value].compareTimes)
-		var value Value
-		if found {
-			value = list[index].value
-		} else {
-			value = missing(t, len(table.values))
-		}
-		values[len(values)-1] = append(values[len(values)-1], value)
-	}
-	times = slices.Clip(times)
-	for i := range values {
-		values[i] = slices.Clip(values[i])
-	}
-	return &Compact[Value]{times: times, values: values}
+func (table *Compact[Value]) addColumn(list List[Value], missing func(time.Time, int) Value, join JoinMode, info ColumnInfo) *Compact[Value] {
+	return table.addColumns([]List[Value]{list}, []ColumnInfo{info}, missing, join)
 }
 
 func (table *Compact[Value]) Between(t0, t1 time.Time) *Compact[Value] {
 	if table.isEmpty() || len(table.times) == 0 {
 		return &Compact[Value]{
-			times:  nil,
-			values: make([][]Value, len(table.values)),
+			times:   nil,
+			values:  make([][]Value, len(table.values)),
+			columns: slices.Clone(table.columns),
 		}
 	}
-	if t1.Before(t0) {
-		t0, t1 = t1, t0
-	}
-
-	list := table.times
-	last := list[len(list)-1]
-
-	var firstIndex, lastIndex int
-	if t0.After(last) {
-		firstIndex = len(list)
-	} else {
-		firstIndex, _ = slices.BinarySearchFunc(list, t0, time.Time.Compare)
-	}
-
-	switch i, ok := slices.BinarySearchFunc(list, t1, time.Time.Compare); {
-	case t1.After(last):
-		lastIndex = len(list)
-	case ok:
-		lastIndex = i + 1
-	default:
-		lastIndex = i
-	}
+	firstIndex, lastIndex := betweenIndexes(table.times, t0, t1, time.Time.Compare)
 
 	values := make([][]Value, len(table.values))
 	for i := range table.values {
 		values[i] = table.values[i][firstIndex:lastIndex:lastIndex]
 	}
+	var missing [][]bool
+	if table.missing != nil {
+		missing = make([][]bool, len(table.missing))
+		for i, mask := range table.missing {
+			if mask != nil {
+				missing[i] = mask[firstIndex:lastIndex:lastIndex]
+			}
+		}
+	}
 	return &Compact[Value]{
-		times:  table.times[firstIndex:lastIndex:lastIndex],
-		values: values,
+		times:   table.times[firstIndex:lastIndex:lastIndex],
+		values:  values,
+		columns: slices.Clone(table.columns),
+		missing: missing,
 	}
 }
@@ -1,6 +1,7 @@
 package timetable_test
 
 import (
+	"fmt"
 	"testing"
 	"time"
 
@@ -242,3 +243,42 @@ func TestCompact_Column(t *testing.T) {
 		assert.Len(t, column, 0)
 	})
 }
+
+func benchmarkLists(columns, rows int) []List {
+	start := date(day0)
+	lists := make([]List, columns)
+	for column := range lists {
+		list := make(List, 0, rows)
+		for row := range rows {
+			if (row+column)%7 == 0 {
+				continue
+			}
+			list = append(list, timetable.NewCell(start.AddDate(0, 0, row), row))
+		}
+		lists[column] = list
+	}
+	return lists
+}
+
+func BenchmarkNew(b *testing.B) {
+	for _, size := range []struct{ Columns, Rows int }{
+		{Columns: 10, Rows: 2500},
+		{Columns: 500, Rows: 2500},
+	} {
+		lists := benchmarkLists(size.Columns, size.Rows)
+		b.Run(fmt.Sprintf("%d columns %d rows", size.Columns, size.Rows), func(b *testing.B) {
+			for range b.N {
+				_ = timetable.New(lists...)
+			}
+		})
+	}
+}
+
+func BenchmarkCompact_AddColumn(b *testing.B) {
+	lists := benchmarkLists(101, 2500)
+	table := timetable.New(lists[:100]...)
+	b.ResetTimer()
+	for range b.N {
+		_ = table.AddColumnFillMissingWithZero(lists[100])
+	}
+}
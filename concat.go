@@ -0,0 +1,133 @@
+package timetable
+
+import (
+	"fmt"
+	"slices"
+	"time"
+)
+
+// OverlapPolicy says what Concat does when both tables have a row at the same time.
+type OverlapPolicy int
+
+const (
+	// OverlapError makes Concat return an error.
+	OverlapError OverlapPolicy = iota
+
+	// OverlapKeepOld keeps the row of the first table.
+	OverlapKeepOld
+
+	// OverlapKeepNew keeps the row of the second table.
+	OverlapKeepNew
+)
+
+func (overlap OverlapPolicy) String() string {
+	switch overlap {
+	case OverlapError:
+		return "error"
+	case OverlapKeepOld:
+		return "keep old"
+	case OverlapKeepNew:
+		return "keep new"
+	default:
+		return "unknown"
+	}
+}
+
+// Concat returns a new table holding the rows of a and b sorted by time.
+// When every column of both tables is named, the columns of b are matched to the columns of a by name;
+// otherwise they are matched by index. The result has the column names and metadata of a.
+// Concat returns an error if the columns do not match or if the tables share a time and overlap is OverlapError.
+// A table without times is treated as having no columns, so concatenating it returns a copy of the other table.
+func Concat[Value any](a, b *Compact[Value], overlap OverlapPolicy) (*Compact[Value], error) {
+	switch {
+	case a.isEmpty() && b.isEmpty():
+		return new(Compact[Value]), nil
+	case a.isEmpty():
+		a, b = b, new(Compact[Value])
+	case b.isEmpty():
+		b = new(Compact[Value])
+	}
+	order, err := matchColumns(a, b)
+	if err != nil {
+		return nil, err
+	}
+
+	type source struct {
+		table *Compact[Value]
+		row   int
+	}
+	times := make([]time.Time, 0, len(a.times)+len(b.times))
+	sources := make([]source, 0, len(a.times)+len(b.times))
+	i, j := 0, 0
+	for i < len(a.times) || j < len(b.times) {
+		switch {
+		case j == len(b.times) || (i < len(a.times) && a.times[i].Before(b.times[j])):
+			times, sources = append(times, a.times[i]), append(sources, source{table: a, row: i})
+			i++
+		case i == len(a.times) || b.times[j].Before(a.times[i]):
+			times, sources = append(times, b.times[j]), append(sources, source{table: b, row: j})
+			j++
+		default:
+			switch overlap {
+			case OverlapKeepOld:
+				sources = append(sources, source{table: a, row: i})
+			case OverlapKeepNew:
+				sources = append(sources, source{table: b, row: j})
+			default:
+				return nil, fmt.Errorf("both tables have a row at %s", a.times[i].Format(time.RFC3339))
+			}
+			times = append(times, a.times[i])
+			i++
+			j++
+		}
+	}
+
+	values := make([][]Value, len(a.values))
+	masks := make([][]bool, len(a.values))
+	for column := range values {
+		values[column] = make([]Value, len(times))
+		masks[column] = make([]bool, len(times))
+		for row, from := range sources {
+			sourceColumn := column
+			if from.table == b {
+				sourceColumn = order[column]
+			}
+			values[column][row] = from.table.values[sourceColumn][from.row]
+			masks[column][row] = from.table.IsMissing(from.row, sourceColumn)
+		}
+	}
+	return &Compact[Value]{times: slices.Clip(times), values: values, columns: slices.Clone(a.columns), missing: compactMask(masks)}, nil
+}
+
+// Upsert returns a new table holding the rows of table with the rows of newer written over them:
+// rows of newer at times the table already has replace those rows, and other rows of newer are inserted in time order.
+// Columns are matched the same way as Concat.
+func Upsert[Value any](table, newer *Compact[Value]) (*Compact[Value], error) {
+	return Concat(table, newer, OverlapKeepNew)
+}
+
+// matchColumns returns, for each column of a, the index of the matching column of b.
+func matchColumns[Value any](a, b *Compact[Value]) ([]int, error) {
+	if len(b.values) == 0 && len(b.times) == 0 {
+		return nil, nil
+	}
+	if len(a.values) != len(b.values) {
+		return nil, fmt.Errorf("can not concatenate a table with %d columns and a table with %d columns", len(a.values), len(b.values))
+	}
+	order := make([]int, len(a.values))
+	aNames, bNames := a.ColumnNames(), b.ColumnNames()
+	if slices.Contains(aNames, "") || slices.Contains(bNames, "") {
+		for column := range order {
+			order[column] = column
+		}
+		return order, nil
+	}
+	for column, name := range aNames {
+		index := slices.Index(bNames, name)
+		if index < 0 {
+			return nil, fmt.Errorf("column %q is missing from the second table", name)
+		}
+		order[column] = index
+	}
+	return order, nil
+}
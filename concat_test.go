@@ -0,0 +1,97 @@
+package timetable_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/portfoliotree/timetable"
+)
+
+func TestConcat(t *testing.T) {
+	old := timetable.New(List{elV(day0, 1), elV(day1, 2)}, List{elV(day0, 10), elV(day1, 20)})
+	newer := timetable.New(List{elV(day1, 3), elV(day3, 4)}, List{elV(day1, 30), elV(day3, 40)})
+
+	for _, tt := range []struct {
+		Overlap timetable.OverlapPolicy
+		Values  [][]Value
+	}{
+		{Overlap: timetable.OverlapKeepOld, Values: [][]Value{{1, 2, 4}, {10, 20, 40}}},
+		{Overlap: timetable.OverlapKeepNew, Values: [][]Value{{1, 3, 4}, {10, 30, 40}}},
+	} {
+		t.Run(tt.Overlap.String(), func(t *testing.T) {
+			result, err := timetable.Concat(old, newer, tt.Overlap)
+			require.NoError(t, err)
+			assert.Equal(t, []time.Time{date(day0), date(day1), date(day3)}, result.Times())
+			assert.Equal(t, tt.Values, result.Values())
+			assert.Equal(t, [][]Value{{1, 2}, {10, 20}}, old.Values(), "it does not modify the tables")
+		})
+	}
+
+	t.Run("overlap error", func(t *testing.T) {
+		_, err := timetable.Concat(old, newer, timetable.OverlapError)
+		assert.Error(t, err)
+	})
+
+	t.Run("without overlap", func(t *testing.T) {
+		later := timetable.New(List{elV(day2, 3)}, List{elV(day2, 30)})
+		result, err := timetable.Concat(later, old, timetable.OverlapError)
+		require.NoError(t, err)
+		assert.Equal(t, []time.Time{date(day0), date(day1), date(day2)}, result.Times())
+		assert.Equal(t, [][]Value{{1, 2, 3}, {10, 20, 30}}, result.Values())
+	})
+
+	t.Run("columns matched by name", func(t *testing.T) {
+		a, err := timetable.NewNamed([]string{"AAA", "BBB"}, List{elV(day0, 1)}, List{elV(day0, 10)})
+		require.NoError(t, err)
+		b, err := timetable.NewNamed([]string{"BBB", "AAA"}, List{elV(day1, 20)}, List{elV(day1, 2)})
+		require.NoError(t, err)
+		result, err := timetable.Concat(a, b, timetable.OverlapError)
+		require.NoError(t, err)
+		assert.Equal(t, [][]Value{{1, 2}, {10, 20}}, result.Values())
+		assert.Equal(t, []string{"AAA", "BBB"}, result.ColumnNames())
+
+		require.NoError(t, b.SetColumnNames("BBB", "CCC"))
+		_, err = timetable.Concat(a, b, timetable.OverlapError)
+		assert.Error(t, err)
+	})
+
+	t.Run("different number of columns", func(t *testing.T) {
+		_, err := timetable.Concat(old, timetable.New(List{elV(day3, 1)}), timetable.OverlapError)
+		assert.Error(t, err)
+	})
+
+	t.Run("empty tables", func(t *testing.T) {
+		result, err := timetable.Concat(nil, old, timetable.OverlapError)
+		require.NoError(t, err)
+		assert.Equal(t, old.Values(), result.Values())
+
+		result, err = timetable.Concat(old, new(Table), timetable.OverlapError)
+		require.NoError(t, err)
+		assert.Equal(t, old.Values(), result.Values())
+
+		result, err = timetable.Concat[Value](nil, nil, timetable.OverlapError)
+		require.NoError(t, err)
+		assert.Equal(t, 0, result.NumberOfColumns())
+	})
+
+	t.Run("missing cells", func(t *testing.T) {
+		result, err := timetable.Concat(maskedTable(), timetable.New(List{elV(dayAfter, 5)}, List{elV(dayAfter, 50)}), timetable.OverlapError)
+		require.NoError(t, err)
+		assert.Equal(t, [][]bool{{false, false, false, false, false}, {false, true, true, false, false}}, missingCells(result))
+	})
+}
+
+func TestUpsert(t *testing.T) {
+	stored, err := timetable.NewNamed([]string{"AAA"}, List{elV(day0, 1), elV(day1, 2), elV(day3, 4)})
+	require.NoError(t, err)
+	fetched, err := timetable.NewNamed([]string{"AAA"}, List{elV(day1, 20), elV(day2, 30)})
+	require.NoError(t, err)
+
+	result, err := timetable.Upsert(stored, fetched)
+	require.NoError(t, err)
+	assert.Equal(t, []time.Time{date(day0), date(day1), date(day2), date(day3)}, result.Times())
+	assert.Equal(t, [][]Value{{1, 20, 30, 4}}, result.Values())
+}
@@ -0,0 +1,165 @@
+package timetable
+
+import (
+	"math"
+	"slices"
+)
+
+// Matrix holds a value for each pair of columns of a table, such as their covariance.
+// Rows and columns are in the column order of the table.
+type Matrix[Value Float] struct {
+	names  []string
+	values []Value
+}
+
+// Len returns the number of rows, which is also the number of columns.
+func (m Matrix[Value]) Len() int { return len(m.names) }
+
+// ColumnNames returns the names of the table columns the matrix was computed from. Unnamed columns have an empty name.
+func (m Matrix[Value]) ColumnNames() []string { return slices.Clone(m.names) }
+
+// At returns the value for the pair of table columns i and j.
+func (m Matrix[Value]) At(i, j int) Value { return m.values[i*len(m.names)+j] }
+
+// Lookup returns the value for the pair of named table columns.
+func (m Matrix[Value]) Lookup(a, b string) (Value, bool) {
+	i, j := slices.Index(m.names, a), slices.Index(m.names, b)
+	if a == "" || b == "" || i < 0 || j < 0 {
+		return 0, false
+	}
+	return m.At(i, j), true
+}
+
+// Values returns a copy of the matrix as a slice of rows.
+func (m Matrix[Value]) Values() [][]Value {
+	rows := make([][]Value, len(m.names))
+	for i := range rows {
+		rows[i] = slices.Clone(m.values[i*len(m.names) : (i+1)*len(m.names)])
+	}
+	return rows
+}
+
+// CovarianceOptions configures Covariance, Correlation and their rolling versions.
+// The zero value weights every row equally.
+type CovarianceOptions struct {
+	// HalfLife weights rows exponentially so that the weight of a row halves every HalfLife rows back from the last row.
+	// It is used when it is greater than zero.
+	HalfLife float64
+}
+
+func (options CovarianceOptions) weight(age int) float64 {
+	if options.HalfLife <= 0 {
+		return 1
+	}
+	return math.Pow(0.5, float64(age)/options.HalfLife)
+}
+
+// Covariance returns the sample covariance of each pair of columns.
+// Each pair uses the rows where both cells are neither missing nor NaN.
+// Pairs with fewer than two such rows have a covariance of NaN.
+func Covariance[Value Float](table *Compact[Value], options CovarianceOptions) Matrix[Value] {
+	return pairwise(table, 0, len(table.times), options, false)
+}
+
+// Correlation returns the Pearson correlation of each pair of columns, using the same rows as Covariance.
+// Pairs where either column does not vary have a correlation of NaN.
+func Correlation[Value Float](table *Compact[Value], options CovarianceOptions) Matrix[Value] {
+	return pairwise(table, 0, len(table.times), options, true)
+}
+
+// RollingCovariance returns the covariance matrix of each window of rows, keyed by the time of the last row in the window.
+// An observation is a row where no cell is missing or NaN, and rows where the window has fewer than its minimum observations hold a missing cell. Weights are relative to the last row of each window.
+func RollingCovariance[Value Float](table *Compact[Value], window Window, options CovarianceOptions) List[Matrix[Value]] {
+	return rollingPairwise(table, window, options, false)
+}
+
+// RollingCorrelation is like RollingCovariance for correlation matrices.
+func RollingCorrelation[Value Float](table *Compact[Value], window Window, options CovarianceOptions) List[Matrix[Value]] {
+	return rollingPairwise(table, window, options, true)
+}
+
+func rollingPairwise[Value Float](table *Compact[Value], window Window, options CovarianceOptions, correlation bool) List[Matrix[Value]] {
+	result := make(List[Matrix[Value]], len(table.times))
+	minObservations := window.minObservations()
+	start := 0
+	for end, t := range table.times {
+		for start < end && window.excludes(table.times, start, end) {
+			start++
+		}
+		if completeRows(table, start, end+1) < minObservations {
+			result[end] = NewMissingCell[Matrix[Value]](t)
+			continue
+		}
+		result[end] = NewCell(t, pairwise(table, start, end+1, options, correlation))
+	}
+	return result
+}
+
+// usable reports whether a cell is neither missing nor NaN.
+func usable[Value Float](table *Compact[Value], column, row int) bool {
+	return !table.IsMissing(row, column) && !math.IsNaN(float64(table.values[column][row]))
+}
+
+// completeRows counts the rows from start to end where every cell is usable.
+func completeRows[Value Float](table *Compact[Value], start, end int) int {
+	count := 0
+rows:
+	for row := start; row < end; row++ {
+		for column := range table.values {
+			if !usable(table, column, row) {
+				continue rows
+			}
+		}
+		count++
+	}
+	return count
+}
+
+// pairwise computes the covariance or correlation matrix of the rows from start to end.
+func pairwise[Value Float](table *Compact[Value], start, end int, options CovarianceOptions, correlation bool) Matrix[Value] {
+	n := len(table.values)
+	m := Matrix[Value]{names: table.ColumnNames(), values: make([]Value, n*n)}
+	weights := make([]float64, end-start)
+	for row := range weights {
+		weights[row] = options.weight(end - start - 1 - row)
+	}
+	for i := range n {
+		for j := i; j < n; j++ {
+			var sumW, sumW2, sumX, sumY float64
+			for row := start; row < end; row++ {
+				if !usable(table, i, row) || !usable(table, j, row) {
+					continue
+				}
+				w := weights[row-start]
+				sumW += w
+				sumW2 += w * w
+				sumX += w * float64(table.values[i][row])
+				sumY += w * float64(table.values[j][row])
+			}
+			value := math.NaN()
+			if denominator := sumW - sumW2/sumW; sumW > 0 && denominator > 0 {
+				meanX, meanY := sumX/sumW, sumY/sumW
+				var covariance, varianceX, varianceY float64
+				for row := start; row < end; row++ {
+					if !usable(table, i, row) || !usable(table, j, row) {
+						continue
+					}
+					w := weights[row-start]
+					dx, dy := float64(table.values[i][row])-meanX, float64(table.values[j][row])-meanY
+					covariance += w * dx * dy
+					varianceX += w * dx * dx
+					varianceY += w * dy * dy
+				}
+				value = covariance / denominator
+				if correlation {
+					value = covariance / math.Sqrt(varianceX*varianceY)
+					if varianceX == 0 || varianceY == 0 {
+						value = math.NaN()
+					}
+				}
+			}
+			m.values[i*n+j], m.values[j*n+i] = Value(value), Value(value)
+		}
+	}
+	return m
+}
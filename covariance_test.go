@@ -0,0 +1,103 @@
+package timetable_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/portfoliotree/timetable"
+)
+
+func floatTable(t *testing.T, names []string, columns ...[]float64) *timetable.Compact[float64] {
+	t.Helper()
+	days := []string{day0, day1, day2, day3, dayAfter}
+	lists := make([]timetable.List[float64], len(columns))
+	for i, column := range columns {
+		for row, value := range column {
+			lists[i] = append(lists[i], timetable.NewCell(date(days[row]), value))
+		}
+	}
+	if names == nil {
+		return timetable.New(lists...)
+	}
+	table, err := timetable.NewNamed(names, lists...)
+	require.NoError(t, err)
+	return table
+}
+
+func TestCovariance(t *testing.T) {
+	table := floatTable(t, []string{"x", "y", "z"},
+		[]float64{1, 2, 3, 4},
+		[]float64{2, 4, 6, 8},
+		[]float64{4, 3, 2, 1},
+	)
+
+	covariance := timetable.Covariance(table, timetable.CovarianceOptions{})
+	assert.Equal(t, 3, covariance.Len())
+	assert.Equal(t, []string{"x", "y", "z"}, covariance.ColumnNames())
+	assert.InDelta(t, 5.0/3, covariance.At(0, 0), 1e-12)
+	assert.InDelta(t, 10.0/3, covariance.At(0, 1), 1e-12)
+	assert.InDelta(t, covariance.At(0, 1), covariance.At(1, 0), 1e-12)
+	value, ok := covariance.Lookup("x", "z")
+	require.True(t, ok)
+	assert.InDelta(t, -5.0/3, value, 1e-12)
+	_, ok = covariance.Lookup("x", "w")
+	assert.False(t, ok)
+
+	correlation := timetable.Correlation(table, timetable.CovarianceOptions{})
+	assert.InDeltaSlice(t, []float64{1, 1, -1}, correlation.Values()[0], 1e-12)
+
+	t.Run("exponential weights", func(t *testing.T) {
+		table := floatTable(t, []string{"x", "y"}, []float64{1, 2, 3}, []float64{1, 3, 2})
+		covariance := timetable.Covariance(table, timetable.CovarianceOptions{HalfLife: 1})
+		assert.InDelta(t, 1.0/7, covariance.At(0, 1), 1e-12)
+	})
+
+	t.Run("pairwise complete", func(t *testing.T) {
+		table := floatTable(t, []string{"x", "y"}, []float64{1, 2, 3, 4}, []float64{2, 4, math.NaN(), 100})
+		require.NoError(t, table.SetMissing(date(day3), 1))
+		covariance := timetable.Covariance(table, timetable.CovarianceOptions{})
+		assert.InDelta(t, 5.0/3, covariance.At(0, 0), 1e-12, "the variance of x uses every row")
+		assert.InDelta(t, 1, covariance.At(0, 1), 1e-12, "the covariance uses the rows where both are present")
+	})
+
+	t.Run("too few rows", func(t *testing.T) {
+		table := floatTable(t, []string{"x"}, []float64{1})
+		assert.True(t, math.IsNaN(timetable.Covariance(table, timetable.CovarianceOptions{}).At(0, 0)))
+	})
+
+	t.Run("constant column", func(t *testing.T) {
+		table := floatTable(t, []string{"x", "y"}, []float64{1, 2, 3}, []float64{1, 1, 1})
+		assert.True(t, math.IsNaN(timetable.Correlation(table, timetable.CovarianceOptions{}).At(0, 1)))
+	})
+}
+
+func TestRollingCorrelation(t *testing.T) {
+	table := floatTable(t, nil, []float64{1, 2, 3, 4}, []float64{1, 2, 3, 1})
+	matrices := timetable.RollingCorrelation(table, timetable.RowWindow(3), timetable.CovarianceOptions{})
+	require.Len(t, matrices, 4)
+	assert.True(t, matrices[0].IsMissing())
+	assert.True(t, matrices[1].IsMissing())
+	assert.Equal(t, date(day2), matrices[2].Time())
+	assert.InDelta(t, 1, matrices[2].Value().At(0, 1), 1e-12)
+	assert.InDelta(t, -0.5, matrices[3].Value().At(0, 1), 1e-12)
+	assert.Equal(t, []string{"", ""}, matrices[3].Value().ColumnNames())
+
+	covariances := timetable.RollingCovariance(table, timetable.RowWindow(2), timetable.CovarianceOptions{})
+	assert.InDelta(t, 0.5, covariances[1].Value().At(0, 1), 1e-12)
+
+	t.Run("missing and NaN cells do not count as observations", func(t *testing.T) {
+		table := floatTable(t, nil, []float64{1, 2, 3, 4}, []float64{1, math.NaN(), 3, 1})
+		require.NoError(t, table.SetMissing(date(day2), 0))
+		matrices := timetable.RollingCorrelation(table, timetable.RowWindow(3), timetable.CovarianceOptions{})
+		assert.True(t, matrices[2].IsMissing())
+		assert.True(t, matrices[3].IsMissing())
+
+		window := timetable.Window{Rows: 4, MinObservations: 2}
+		matrices = timetable.RollingCorrelation(table, window, timetable.CovarianceOptions{})
+		assert.True(t, matrices[2].IsMissing())
+		assert.False(t, matrices[3].IsMissing())
+	})
+}
@@ -0,0 +1,248 @@
+package timetable
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"time"
+)
+
+// CSVOptions configures ReadCSV and WriteCSV.
+// The zero value reads and writes a first column of dates in the form 2006-01-02 followed by one column per series.
+type CSVOptions[Value any] struct {
+	// TimeLayout is the layout of the time column. It defaults to time.DateOnly.
+	TimeLayout string
+
+	// Location is used to parse times without a zone and times are converted to it before they are written.
+	// It defaults to UTC when reading and leaves times unchanged when writing.
+	Location *time.Location
+
+	// TimeColumn is the index of the time column in each record.
+	TimeColumn int
+
+	// Header means the first record holds column names.
+	// When reading, the names are assigned to the columns of the table.
+	Header bool
+
+	// TimeHeader is the name written above the time column. It defaults to "time".
+	TimeHeader string
+
+	// Comma is the field delimiter. It defaults to ','.
+	Comma rune
+
+	// Parse converts a cell to a Value. It is required for reading.
+	Parse func(string) (Value, error)
+
+	// Format converts a Value to a cell. It defaults to fmt.Sprint.
+	Format func(Value) string
+
+	// Missing returns the value stored for a blank cell. When it is nil, blank cells are marked missing.
+	// Missing cells are written as blank cells.
+	Missing func(time.Time, int) Value
+}
+
+func (options CSVOptions[Value]) timeLayout() string {
+	if options.TimeLayout == "" {
+		return time.DateOnly
+	}
+	return options.TimeLayout
+}
+
+// CSVError reports a problem with a cell of a CSV file. Row and Column start at one.
+type CSVError struct {
+	Row, Column int
+	Err         error
+}
+
+func (err *CSVError) Error() string {
+	return fmt.Sprintf("csv row %d column %d: %s", err.Row, err.Column, err.Err)
+}
+
+func (err *CSVError) Unwrap() error { return err.Err }
+
+// ReadCSV reads a table from CSV records holding a time followed by one value per column.
+// Records do not need to be sorted by time, but each time may only appear once.
+func ReadCSV[Value any](r io.Reader, options CSVOptions[Value]) (*Compact[Value], error) {
+	if options.Parse == nil {
+		return nil, errors.New("CSVOptions.Parse is required to read CSV")
+	}
+	loc := options.Location
+	if loc == nil {
+		loc = time.UTC
+	}
+	reader := csv.NewReader(r)
+	if options.Comma != 0 {
+		reader.Comma = options.Comma
+	}
+	reader.ReuseRecord = true
+
+	type row struct {
+		time    time.Time
+		line    int
+		values  []Value
+		missing []bool
+	}
+	var (
+		rows  []row
+		names []string
+	)
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if options.TimeColumn < 0 || options.TimeColumn >= len(record) {
+			line, _ := reader.FieldPos(0)
+			return nil, &CSVError{Row: line, Column: options.TimeColumn + 1, Err: errors.New("missing time column")}
+		}
+		if options.Header && names == nil {
+			names = make([]string, 0, len(record)-1)
+			for field, name := range record {
+				if field != options.TimeColumn {
+					names = append(names, strings.TrimSpace(name))
+				}
+			}
+			continue
+		}
+		line, _ := reader.FieldPos(options.TimeColumn)
+		t, err := time.ParseInLocation(options.timeLayout(), strings.TrimSpace(record[options.TimeColumn]), loc)
+		if err != nil {
+			return nil, &CSVError{Row: line, Column: options.TimeColumn + 1, Err: err}
+		}
+		values := make([]Value, 0, len(record)-1)
+		missing := make([]bool, 0, len(record)-1)
+		for field, cell := range record {
+			if field == options.TimeColumn {
+				continue
+			}
+			if strings.TrimSpace(cell) == "" {
+				var value Value
+				if options.Missing != nil {
+					value = options.Missing(t, len(values))
+				}
+				values = append(values, value)
+				missing = append(missing, options.Missing == nil)
+				continue
+			}
+			value, err := options.Parse(cell)
+			if err != nil {
+				return nil, &CSVError{Row: line, Column: field + 1, Err: err}
+			}
+			values = append(values, value)
+			missing = append(missing, false)
+		}
+		rows = append(rows, row{time: t, line: line, values: values, missing: missing})
+	}
+
+	slices.SortStableFunc(rows, func(a, b row) int { return a.time.Compare(b.time) })
+	for i := 1; i < len(rows); i++ {
+		if rows[i].time.Equal(rows[i-1].time) {
+			return nil, &CSVError{Row: max(rows[i].line, rows[i-1].line), Column: options.TimeColumn + 1, Err: fmt.Errorf("duplicate time %s", rows[i].time.Format(options.timeLayout()))}
+		}
+	}
+
+	numberOfColumns := len(names)
+	if len(rows) > 0 {
+		numberOfColumns = len(rows[0].values)
+	}
+	table := &Compact[Value]{
+		times:  make([]time.Time, len(rows)),
+		values: make([][]Value, numberOfColumns),
+	}
+	for column := range table.values {
+		table.values[column] = make([]Value, len(rows))
+	}
+	for i, row := range rows {
+		table.times[i] = row.time
+		for column, value := range row.values {
+			table.values[column][i] = value
+			if row.missing[column] {
+				table.setMissing(i, column, true)
+			}
+		}
+	}
+	if names != nil {
+		if err := table.SetColumnNames(names...); err != nil {
+			return nil, &CSVError{Row: 1, Column: 1, Err: err}
+		}
+	}
+	return table, nil
+}
+
+// ReadListCSV is like ReadCSV for records with a single value column.
+func ReadListCSV[Value any](r io.Reader, options CSVOptions[Value]) (List[Value], error) {
+	table, err := ReadCSV(r, options)
+	if err != nil {
+		return nil, err
+	}
+	if table.NumberOfColumns() != 1 {
+		return nil, fmt.Errorf("expected one value column got %d", table.NumberOfColumns())
+	}
+	return listColumn(table), nil
+}
+
+// WriteCSV writes the table as CSV records holding a time followed by one value per column.
+func (table *Compact[Value]) WriteCSV(w io.Writer, options CSVOptions[Value]) error {
+	format := options.Format
+	if format == nil {
+		format = func(value Value) string { return fmt.Sprint(value) }
+	}
+	writer := csv.NewWriter(w)
+	if options.Comma != 0 {
+		writer.Comma = options.Comma
+	}
+	width := len(table.values) + 1
+	timeColumn := min(max(options.TimeColumn, 0), width-1)
+	record := make([]string, width)
+	fill := func(timeCell string, cell func(column int) string) {
+		for field := range record {
+			switch {
+			case field == timeColumn:
+				record[field] = timeCell
+			case field < timeColumn:
+				record[field] = cell(field)
+			default:
+				record[field] = cell(field - 1)
+			}
+		}
+	}
+	if options.Header {
+		timeHeader := options.TimeHeader
+		if timeHeader == "" {
+			timeHeader = "time"
+		}
+		names := table.ColumnNames()
+		fill(timeHeader, func(column int) string { return names[column] })
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+	for row, t := range table.times {
+		if options.Location != nil {
+			t = t.In(options.Location)
+		}
+		fill(t.Format(options.timeLayout()), func(column int) string {
+			if table.IsMissing(row, column) {
+				return ""
+			}
+			return format(table.values[column][row])
+		})
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// WriteCSV writes the list as CSV records holding a time followed by a value.
+func (list List[Value]) WriteCSV(w io.Writer, options CSVOptions[Value]) error {
+	table := New(slices.Clone(list))
+	return table.WriteCSV(w, options)
+}
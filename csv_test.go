@@ -0,0 +1,157 @@
+package timetable_test
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/portfoliotree/timetable"
+)
+
+func csvOptions() timetable.CSVOptions[Value] {
+	return timetable.CSVOptions[Value]{
+		Header: true,
+		Parse:  strconv.Atoi,
+	}
+}
+
+func TestReadCSV(t *testing.T) {
+	t.Run("header and unsorted rows", func(t *testing.T) {
+		table, err := timetable.ReadCSV(strings.NewReader(`date,AAA,BBB
+2022-10-21,2,20
+2022-10-20,1,10
+2022-10-24,,30
+`), csvOptions())
+		require.NoError(t, err)
+		assert.Equal(t, []string{"AAA", "BBB"}, table.ColumnNames())
+		assert.Equal(t, []time.Time{date(day0), date(day1), date(day2)}, table.Times())
+		assert.Equal(t, [][]Value{{1, 2, 0}, {10, 20, 30}}, table.Values())
+		assert.True(t, table.IsMissing(2, 0), "blank cells are missing")
+		assert.False(t, table.IsMissing(2, 1))
+	})
+
+	t.Run("blank cells use the missing function", func(t *testing.T) {
+		options := csvOptions()
+		options.Missing = missingNegativeOne
+		table, err := timetable.ReadCSV(strings.NewReader("date,AAA\n2022-10-20,\n"), options)
+		require.NoError(t, err)
+		assert.Equal(t, [][]Value{{-1}}, table.Values())
+	})
+
+	t.Run("time layout, location and time column", func(t *testing.T) {
+		loc := time.FixedZone("EST", -5*60*60)
+		table, err := timetable.ReadCSV(strings.NewReader("1;10/20/2022 16:00\n"), timetable.CSVOptions[Value]{
+			TimeLayout: "01/02/2006 15:04",
+			Location:   loc,
+			TimeColumn: 1,
+			Comma:      ';',
+			Parse:      strconv.Atoi,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []time.Time{time.Date(2022, 10, 20, 16, 0, 0, 0, loc)}, table.Times())
+		assert.Equal(t, [][]Value{{1}}, table.Values())
+		assert.Equal(t, []string{""}, table.ColumnNames())
+	})
+
+	t.Run("bad value", func(t *testing.T) {
+		_, err := timetable.ReadCSV(strings.NewReader("date,AAA,BBB\n2022-10-20,1,10\n2022-10-21,2,x\n"), csvOptions())
+		var csvErr *timetable.CSVError
+		if assert.ErrorAs(t, err, &csvErr) {
+			assert.Equal(t, 3, csvErr.Row)
+			assert.Equal(t, 3, csvErr.Column)
+		}
+		assert.ErrorIs(t, err, strconv.ErrSyntax)
+	})
+
+	t.Run("bad time", func(t *testing.T) {
+		_, err := timetable.ReadCSV(strings.NewReader("date,AAA\nyesterday,1\n"), csvOptions())
+		assert.EqualError(t, err, `csv row 2 column 1: parsing time "yesterday" as "2006-01-02": cannot parse "yesterday" as "2006"`)
+	})
+
+	t.Run("duplicate time", func(t *testing.T) {
+		_, err := timetable.ReadCSV(strings.NewReader("date,AAA\n2022-10-20,1\n2022-10-21,2\n2022-10-20,3\n"), csvOptions())
+		assert.EqualError(t, err, "csv row 4 column 1: duplicate time 2022-10-20")
+	})
+
+	t.Run("duplicate column names", func(t *testing.T) {
+		_, err := timetable.ReadCSV(strings.NewReader("date,AAA,AAA\n2022-10-20,1,2\n"), csvOptions())
+		assert.ErrorContains(t, err, "duplicate column name")
+	})
+
+	t.Run("wrong number of fields", func(t *testing.T) {
+		_, err := timetable.ReadCSV(strings.NewReader("date,AAA\n2022-10-20,1,2\n"), csvOptions())
+		assert.ErrorContains(t, err, "line 2")
+	})
+
+	t.Run("without a parser", func(t *testing.T) {
+		_, err := timetable.ReadCSV(strings.NewReader(""), timetable.CSVOptions[Value]{})
+		assert.Error(t, err)
+	})
+
+	t.Run("header only", func(t *testing.T) {
+		table, err := timetable.ReadCSV(strings.NewReader("date,AAA,BBB\n"), csvOptions())
+		require.NoError(t, err)
+		assert.Equal(t, 0, table.NumberOfRows())
+		assert.Equal(t, []string{"AAA", "BBB"}, table.ColumnNames())
+	})
+}
+
+func TestReadListCSV(t *testing.T) {
+	list, err := timetable.ReadListCSV(strings.NewReader("2022-10-21,2\n2022-10-20,1\n"), timetable.CSVOptions[Value]{Parse: strconv.Atoi})
+	require.NoError(t, err)
+	assert.Equal(t, List{elV(day0, 1), elV(day1, 2)}, list)
+
+	_, err = timetable.ReadListCSV(strings.NewReader("2022-10-21,2,3\n"), timetable.CSVOptions[Value]{Parse: strconv.Atoi})
+	assert.Error(t, err)
+}
+
+func TestCompact_WriteCSV(t *testing.T) {
+	table, err := timetable.NewNamed([]string{"AAA", "BBB"},
+		List{elV(day0, 1), elV(day1, 2)},
+		List{elV(day0, 10), elV(day1, 20)},
+	)
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, table.WriteCSV(&buf, csvOptions()))
+	assert.Equal(t, "time,AAA,BBB\n2022-10-20,1,10\n2022-10-21,2,20\n", buf.String())
+
+	t.Run("round trip", func(t *testing.T) {
+		decoded, err := timetable.ReadCSV(&buf, csvOptions())
+		require.NoError(t, err)
+		assert.Equal(t, table.ColumnNames(), decoded.ColumnNames())
+		assert.Equal(t, table.Times(), decoded.Times())
+		assert.Equal(t, table.Values(), decoded.Values())
+	})
+
+	t.Run("missing cells are blank", func(t *testing.T) {
+		masked := timetable.New(List{elV(day0, 1), elV(day1, 2)}).AddColumnWithJoin(List{elV(day1, 20)}, nil, timetable.JoinLeft)
+		var buf bytes.Buffer
+		require.NoError(t, masked.WriteCSV(&buf, timetable.CSVOptions[Value]{}))
+		assert.Equal(t, "2022-10-20,1,\n2022-10-21,2,20\n", buf.String())
+
+		decoded, err := timetable.ReadCSV(&buf, timetable.CSVOptions[Value]{Parse: strconv.Atoi})
+		require.NoError(t, err)
+		assert.True(t, decoded.IsMissing(0, 1))
+	})
+
+	t.Run("time column and format", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, table.WriteCSV(&buf, timetable.CSVOptions[Value]{
+			TimeColumn: 2,
+			Format:     func(v Value) string { return strconv.Itoa(v * 100) },
+		}))
+		assert.Equal(t, "100,1000,2022-10-20\n200,2000,2022-10-21\n", buf.String())
+	})
+}
+
+func TestList_WriteCSV(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, List{elV(day1, 2), elV(day0, 1)}.WriteCSV(&buf, timetable.CSVOptions[Value]{}))
+	assert.Equal(t, "2022-10-20,1\n2022-10-21,2\n", buf.String())
+}
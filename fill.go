@@ -0,0 +1,141 @@
+package timetable
+
+import (
+	"math"
+	"slices"
+	"time"
+)
+
+// Filler replaces missing cells in one column using the cells around them.
+// The missing slice reports which values need filling.
+// A Filler sets missing[i] to false for every cell it fills and leaves the rest untouched.
+type Filler[Value any] func(times []time.Time, values []Value, missing []bool)
+
+// ForwardFill carries the last value forward into each gap.
+// Gaps longer than maxGap cells are left unfilled; a maxGap of zero or less means there is no limit.
+func ForwardFill[Value any](maxGap int) Filler[Value] {
+	return func(_ []time.Time, values []Value, missing []bool) {
+		forEachGap(missing, maxGap, func(start, end int) {
+			if start == 0 {
+				return
+			}
+			for i := start; i < end; i++ {
+				values[i] = values[start-1]
+				missing[i] = false
+			}
+		})
+	}
+}
+
+// BackwardFill carries the next value back into each gap.
+// Gaps longer than maxGap cells are left unfilled; a maxGap of zero or less means there is no limit.
+func BackwardFill[Value any](maxGap int) Filler[Value] {
+	return func(_ []time.Time, values []Value, missing []bool) {
+		forEachGap(missing, maxGap, func(start, end int) {
+			if end == len(values) {
+				return
+			}
+			for i := start; i < end; i++ {
+				values[i] = values[end]
+				missing[i] = false
+			}
+		})
+	}
+}
+
+// LinearInterpolation fills each gap on a straight line between the values on either side,
+// weighted by time. Gaps at the start or end of a column have only one neighbour and are left unfilled.
+// Integer values are rounded to the nearest integer.
+// Gaps longer than maxGap cells are left unfilled; a maxGap of zero or less means there is no limit.
+func LinearInterpolation[Value Number](maxGap int) Filler[Value] {
+	return func(times []time.Time, values []Value, missing []bool) {
+		forEachGap(missing, maxGap, func(start, end int) {
+			if start == 0 || end == len(values) {
+				return
+			}
+			t0, t1 := times[start-1], times[end]
+			v0, v1 := float64(values[start-1]), float64(values[end])
+			span := float64(t1.Sub(t0))
+			for i := start; i < end; i++ {
+				fraction := float64(times[i].Sub(t0)) / span
+				values[i] = fromFloat[Value](v0 + (v1-v0)*fraction)
+				missing[i] = false
+			}
+		})
+	}
+}
+
+// ConstantFill replaces every missing cell with value.
+func ConstantFill[Value any](value Value) Filler[Value] {
+	return func(_ []time.Time, values []Value, missing []bool) {
+		for i := range values {
+			if missing[i] {
+				values[i] = value
+				missing[i] = false
+			}
+		}
+	}
+}
+
+func fromFloat[Value Number](f float64) Value {
+	var zero Value
+	switch any(zero).(type) {
+	case float32, float64:
+		return Value(f)
+	default:
+		return Value(math.Round(f))
+	}
+}
+
+// forEachGap calls fn with the bounds of every run of missing cells no longer than maxGap.
+func forEachGap(missing []bool, maxGap int, fn func(start, end int)) {
+	for start := 0; start < len(missing); start++ {
+		if !missing[start] {
+			continue
+		}
+		end := start + 1
+		for end < len(missing) && missing[end] {
+			end++
+		}
+		if maxGap <= 0 || end-start <= maxGap {
+			fn(start, end)
+		}
+		start = end
+	}
+}
+
+// AddColumnWithFill is like AddColumnWithJoin but fills the missing cells of the result using fill.
+// Cells fill leaves unfilled hold the zero value and are marked missing.
+func (table *Compact[Value]) AddColumnWithFill(list List[Value], fill Filler[Value], join JoinMode) *Compact[Value] {
+	updated, _ := table.joinColumns([]List[Value]{list}, nil, join)
+	updated.columns = table.joinedColumnInfos([]ColumnInfo{{}})
+	for column, missing := range updated.missing {
+		if missing != nil {
+			fill(updated.times, updated.values[column], missing)
+		}
+	}
+	updated.missing = compactMask(updated.missing)
+	return updated
+}
+
+// Fill returns a copy of the table where each missing cell and each cell that isMissing reports as missing
+// is replaced using fill. isMissing may be nil. Cells fill leaves unfilled keep their original value
+// and are marked missing.
+func (table *Compact[Value]) Fill(isMissing func(t time.Time, column int, value Value) bool, fill Filler[Value]) *Compact[Value] {
+	updated := &Compact[Value]{
+		times:   slices.Clip(table.times),
+		values:  table.Values(),
+		columns: slices.Clone(table.columns),
+	}
+	masks := make([][]bool, len(updated.values))
+	for column, values := range updated.values {
+		missing := make([]bool, len(table.times))
+		for row, t := range updated.times {
+			missing[row] = table.IsMissing(row, column) || (isMissing != nil && isMissing(t, column, values[row]))
+		}
+		fill(updated.times, values, missing)
+		masks[column] = missing
+	}
+	updated.missing = compactMask(masks)
+	return updated
+}
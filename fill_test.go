@@ -0,0 +1,103 @@
+package timetable_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/portfoliotree/timetable"
+)
+
+func TestFiller(t *testing.T) {
+	times := []time.Time{date(day0), date(day1), date(day2), date(day3), date(dayAfter)}
+
+	for _, tt := range []struct {
+		Name    string
+		Fill    timetable.Filler[Value]
+		Values  []Value
+		Missing []bool
+		Result  []Value
+		Left    []bool
+	}{
+		{
+			Name:    "forward fill",
+			Fill:    timetable.ForwardFill[Value](0),
+			Values:  []Value{0, 1, 0, 0, 5},
+			Missing: []bool{true, false, true, true, false},
+			Result:  []Value{0, 1, 1, 1, 5},
+			Left:    []bool{true, false, false, false, false},
+		},
+		{
+			Name:    "forward fill with a max gap",
+			Fill:    timetable.ForwardFill[Value](1),
+			Values:  []Value{1, 0, 3, 0, 0},
+			Missing: []bool{false, true, false, true, true},
+			Result:  []Value{1, 1, 3, 0, 0},
+			Left:    []bool{false, false, false, true, true},
+		},
+		{
+			Name:    "backward fill",
+			Fill:    timetable.BackwardFill[Value](0),
+			Values:  []Value{0, 1, 0, 0, 5},
+			Missing: []bool{true, false, true, true, false},
+			Result:  []Value{1, 1, 5, 5, 5},
+			Left:    []bool{false, false, false, false, false},
+		},
+		{
+			Name:    "backward fill leaves the end",
+			Fill:    timetable.BackwardFill[Value](2),
+			Values:  []Value{0, 0, 0, 4, 0},
+			Missing: []bool{true, true, true, false, true},
+			Result:  []Value{0, 0, 0, 4, 0},
+			Left:    []bool{true, true, true, false, true},
+		},
+		{
+			Name:    "linear interpolation by time",
+			Fill:    timetable.LinearInterpolation[Value](0),
+			Values:  []Value{0, 10, 0, 0, 50},
+			Missing: []bool{true, false, true, true, false},
+			// day1 is a Friday, so day2 is three days later and dayAfter five days later.
+			Result: []Value{0, 10, 34, 42, 50},
+			Left:   []bool{true, false, false, false, false},
+		},
+	} {
+		t.Run(tt.Name, func(t *testing.T) {
+			tt.Fill(times, tt.Values, tt.Missing)
+			assert.Equal(t, tt.Result, tt.Values)
+			assert.Equal(t, tt.Left, tt.Missing)
+		})
+	}
+
+	t.Run("linear interpolation of floats", func(t *testing.T) {
+		values := []float64{1, 0, 2}
+		missing := []bool{false, true, false}
+		timetable.LinearInterpolation[float64](0)(times[:3], values, missing)
+		assert.InDelta(t, 1.25, values[1], 1e-9)
+	})
+}
+
+func TestCompact_AddColumnWithFill(t *testing.T) {
+	table := timetable.New(List{elV(day0, 1), elV(day1, 2), elV(day2, 3), elV(day3, 4)})
+	result := table.AddColumnWithFill(List{elV(day1, 20), elV(dayAfter, 50)}, timetable.ForwardFill[Value](0), timetable.JoinOuter)
+	assert.Equal(t, []time.Time{date(day0), date(day1), date(day2), date(day3), date(dayAfter)}, result.Times())
+	assert.Equal(t, [][]Value{
+		{1, 2, 3, 4, 4},
+		{0, 20, 20, 20, 50},
+	}, result.Values())
+}
+
+func TestCompact_Fill(t *testing.T) {
+	table := timetable.New(
+		timetable.List[float64]{
+			timetable.NewCell(date(day0), 1.0),
+			timetable.NewCell(date(day1), math.NaN()),
+			timetable.NewCell(date(day2), 3.0),
+		},
+	)
+	isNaN := func(_ time.Time, _ int, value float64) bool { return math.IsNaN(value) }
+	result := table.Fill(isNaN, timetable.ForwardFill[float64](0))
+	assert.Equal(t, [][]float64{{1, 1, 3}}, result.Values())
+	assert.True(t, math.IsNaN(table.UnderlyingValues()[0][1]), "it does not modify the table")
+}
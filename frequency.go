@@ -0,0 +1,163 @@
+package timetable
+
+import (
+	"slices"
+	"time"
+)
+
+// Frequency is how often a series is expected to have a row.
+// Periods of a frequency are computed in the location of each time.
+type Frequency int
+
+const (
+	// Irregular is a series with no regular frequency. It expects no periods.
+	Irregular Frequency = iota
+
+	// Daily expects a row on every calendar day.
+	Daily
+
+	// BusinessDaily expects a row on every day from Monday through Friday.
+	BusinessDaily
+
+	// Weekly expects a row in every week. Weeks start on Monday, following ISO 8601.
+	Weekly
+
+	Monthly
+	Quarterly
+	Yearly
+)
+
+func (frequency Frequency) String() string {
+	switch frequency {
+	case Irregular:
+		return "irregular"
+	case Daily:
+		return "daily"
+	case BusinessDaily:
+		return "business daily"
+	case Weekly:
+		return "weekly"
+	case Monthly:
+		return "monthly"
+	case Quarterly:
+		return "quarterly"
+	case Yearly:
+		return "yearly"
+	default:
+		return "unknown"
+	}
+}
+
+// PeriodStart returns the first instant of the period containing t. For Irregular it returns t.
+func (frequency Frequency) PeriodStart(t time.Time) time.Time {
+	switch frequency {
+	case Daily, BusinessDaily:
+		year, month, day := t.Date()
+		return time.Date(year, month, day, 0, 0, 0, 0, t.Location())
+	case Weekly:
+		return Week.Start(t, nil)
+	case Monthly:
+		return Month.Start(t, nil)
+	case Quarterly:
+		return Quarter.Start(t, nil)
+	case Yearly:
+		return Year.Start(t, nil)
+	default:
+		return t
+	}
+}
+
+// Periods returns the start of each period from the period containing t0 to the period containing t1, in the location of t0.
+// For Irregular it returns nil.
+func (frequency Frequency) Periods(t0, t1 time.Time) []time.Time {
+	if t1.Before(t0) {
+		t0, t1 = t1, t0
+	}
+	if frequency == BusinessDaily {
+		return WeekdayCalendar().TradingDays(t0, t1)
+	}
+	years, months, days := frequency.step()
+	if years+months+days == 0 {
+		return nil
+	}
+	var periods []time.Time
+	for t := frequency.PeriodStart(t0); !t.After(t1); t = t.AddDate(years, months, days) {
+		periods = append(periods, t)
+	}
+	return periods
+}
+
+func (frequency Frequency) step() (years, months, days int) {
+	switch frequency {
+	case Daily:
+		return 0, 0, 1
+	case Weekly:
+		return 0, 0, 7
+	case Monthly:
+		return 0, 1, 0
+	case Quarterly:
+		return 0, 3, 0
+	case Yearly:
+		return 1, 0, 0
+	default:
+		return 0, 0, 0
+	}
+}
+
+// inferredFrequencies are tried in order by InferFrequency.
+// BusinessDaily comes before Daily because weekday series with holidays also fit Daily.
+var inferredFrequencies = []Frequency{BusinessDaily, Daily, Weekly, Monthly, Quarterly, Yearly}
+
+// InferFrequency returns the frequency of sorted times. A frequency fits when every time is in a different period,
+// at least half of the times are in the period after the previous time, and, for BusinessDaily, no time is on a weekend.
+// So holes in a series do not change its frequency. It returns Irregular when no frequency fits or there are fewer than two times.
+func InferFrequency(times []time.Time) Frequency {
+	if len(times) < 2 {
+		return Irregular
+	}
+	for _, frequency := range inferredFrequencies {
+		if frequency.fits(times) {
+			return frequency
+		}
+	}
+	return Irregular
+}
+
+func (frequency Frequency) fits(times []time.Time) bool {
+	if frequency == BusinessDaily {
+		weekdays := WeekdayCalendar()
+		for _, t := range times {
+			if !weekdays.IsTradingDay(t) {
+				return false
+			}
+		}
+	}
+	adjacent := 0
+	for i := 1; i < len(times); i++ {
+		switch steps := len(frequency.Periods(times[i-1], times[i].In(times[i-1].Location()))) - 1; steps {
+		case 0:
+			return false
+		case 1:
+			adjacent++
+		}
+	}
+	return 2*adjacent >= len(times)-1
+}
+
+// InferFrequency is like the InferFrequency function for the times of the table.
+func (table *Compact[Value]) InferFrequency() Frequency {
+	if table.isEmpty() {
+		return Irregular
+	}
+	return InferFrequency(table.times)
+}
+
+// InferFrequency is like the InferFrequency function for the times of the list.
+func (list List[Value]) InferFrequency() Frequency {
+	times := make([]time.Time, len(list))
+	for i, cell := range list {
+		times[i] = cell.time
+	}
+	slices.SortFunc(times, time.Time.Compare)
+	return InferFrequency(times)
+}
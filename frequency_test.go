@@ -0,0 +1,63 @@
+package timetable_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/portfoliotree/timetable"
+)
+
+func dates(values ...string) []time.Time {
+	times := make([]time.Time, len(values))
+	for i, value := range values {
+		times[i] = date(value)
+	}
+	return times
+}
+
+func TestInferFrequency(t *testing.T) {
+	for _, tt := range []struct {
+		Name   string
+		Times  []time.Time
+		Result timetable.Frequency
+	}{
+		{Name: "business days", Times: dates(day0, day1, day2, day3), Result: timetable.BusinessDaily},
+		{Name: "business days with a holiday", Times: dates(day0, day2, day3, dayAfter), Result: timetable.BusinessDaily},
+		{Name: "calendar days", Times: dates("2022-10-20", "2022-10-21", "2022-10-22", "2022-10-23", "2022-10-24"), Result: timetable.Daily},
+		{Name: "weeks", Times: dates("2022-10-07", "2022-10-14", "2022-10-21", "2022-11-04"), Result: timetable.Weekly},
+		{Name: "month ends", Times: dates("2022-07-29", "2022-08-31", "2022-09-30", "2022-10-31"), Result: timetable.Monthly},
+		{Name: "quarter ends", Times: dates("2022-03-31", "2022-06-30", "2022-09-30", "2022-12-30"), Result: timetable.Quarterly},
+		{Name: "years", Times: dates("2019-12-31", "2020-12-31", "2021-12-31"), Result: timetable.Yearly},
+		{Name: "mostly holes", Times: dates("2022-01-31", "2022-04-30", "2022-05-31", "2022-09-30"), Result: timetable.Irregular},
+		{Name: "two rows in a day", Times: []time.Time{date(day0), date(day0).Add(time.Hour), date(day1)}, Result: timetable.Irregular},
+		{Name: "one time", Times: dates(day0), Result: timetable.Irregular},
+	} {
+		t.Run(tt.Name, func(t *testing.T) {
+			assert.Equal(t, tt.Result, timetable.InferFrequency(tt.Times))
+		})
+	}
+
+	t.Run("list", func(t *testing.T) {
+		assert.Equal(t, timetable.BusinessDaily, List{elV(day2, 1), elV(day0, 1), elV(day1, 1)}.InferFrequency())
+	})
+
+	t.Run("table", func(t *testing.T) {
+		assert.Equal(t, timetable.BusinessDaily, maskedTable().InferFrequency())
+		var table *Table
+		assert.Equal(t, timetable.Irregular, table.InferFrequency())
+	})
+}
+
+func TestFrequency_Periods(t *testing.T) {
+	assert.Equal(t, dates(day1, day2, day3), timetable.BusinessDaily.Periods(date(day1), date(day3)))
+	assert.Equal(t, dates("2022-10-22", "2022-10-23"), timetable.Daily.Periods(date("2022-10-22").Add(time.Hour), date("2022-10-23")))
+	assert.Equal(t, dates("2022-10-17", "2022-10-24"), timetable.Weekly.Periods(date(day0), date(day2)))
+	assert.Equal(t, dates("2022-07-01", "2022-10-01"), timetable.Quarterly.Periods(date(dayAfter), date("2022-08-15")))
+	assert.Nil(t, timetable.Irregular.Periods(date(day0), date(day3)))
+
+	assert.Equal(t, date("2022-10-01"), timetable.Monthly.PeriodStart(date(day0)))
+	assert.Equal(t, date(day0), timetable.Irregular.PeriodStart(date(day0)))
+	assert.Equal(t, "business daily", timetable.BusinessDaily.String())
+}
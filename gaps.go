@@ -0,0 +1,137 @@
+package timetable
+
+import (
+	"slices"
+	"time"
+)
+
+// Schedule says which periods a series is expected to have an observation in. Frequency and Calendar are schedules.
+type Schedule interface {
+	// PeriodStart returns the start of the period containing t.
+	PeriodStart(t time.Time) time.Time
+
+	// Periods returns the start of each expected period from the period containing t0 to the period containing t1.
+	Periods(t0, t1 time.Time) []time.Time
+}
+
+// Gap is a run of consecutive expected periods with no observation.
+type Gap struct {
+	// Start is the start of the first missing period and End is the start of the last one.
+	Start, End time.Time
+
+	// Periods is the number of missing periods.
+	Periods int
+}
+
+// gaps returns the runs of periods of the schedule between the first and last of the sorted times that hold none of the times.
+// Periods are computed in the location of the first time.
+func gaps(times []time.Time, schedule Schedule) []Gap {
+	if len(times) == 0 {
+		return nil
+	}
+	loc := times[0].Location()
+	observed := make(map[int64]struct{}, len(times))
+	for _, t := range times {
+		observed[schedule.PeriodStart(t.In(loc)).Unix()] = struct{}{}
+	}
+	var (
+		result []Gap
+		inGap  bool
+	)
+	for _, period := range schedule.Periods(times[0], times[len(times)-1].In(loc)) {
+		if _, found := observed[schedule.PeriodStart(period).Unix()]; found {
+			inGap = false
+			continue
+		}
+		if !inGap {
+			result = append(result, Gap{Start: period})
+			inGap = true
+		}
+		result[len(result)-1].End = period
+		result[len(result)-1].Periods++
+	}
+	return result
+}
+
+// Gaps returns the expected periods of the schedule between the first and last row that have no row
+// where at least one cell is not missing.
+func (table *Compact[Value]) Gaps(schedule Schedule) []Gap {
+	if table.isEmpty() {
+		return nil
+	}
+	var times []time.Time
+	for row, t := range table.times {
+		if len(table.values) == 0 || slices.Contains(table.rowMissing(row), false) {
+			times = append(times, t)
+		}
+	}
+	return gaps(times, schedule)
+}
+
+// Gaps returns the expected periods of the schedule between the first and last cell that is not missing
+// that have no cell that is not missing. The list is not modified.
+func (list List[Value]) Gaps(schedule Schedule) []Gap {
+	return gaps(observedTimes(list), schedule)
+}
+
+// observedTimes returns the sorted times of the cells that are not missing.
+func observedTimes[Value any](list List[Value]) []time.Time {
+	var times []time.Time
+	for _, cell := range list {
+		if !cell.missing {
+			times = append(times, cell.time)
+		}
+	}
+	slices.SortFunc(times, time.Time.Compare)
+	return times
+}
+
+// ColumnQuality describes the observations in one column of a table.
+type ColumnQuality struct {
+	// Column is the name of the column. It is empty for unnamed columns.
+	Column string
+
+	// First and Last are the times of the first and last cells that are not missing. They are the zero time when Count is zero.
+	First, Last time.Time
+
+	// Count is the number of cells that are not missing.
+	Count int
+
+	// Missing is the number of missing cells.
+	Missing int
+
+	// MissingPeriods is the number of expected periods from First to Last with no cell that is not missing.
+	MissingPeriods int
+
+	// LongestGap is the longest run of missing periods. It is the zero Gap when no period is missing.
+	LongestGap Gap
+}
+
+// Quality returns a report on the observations of each column measured against the schedule.
+// A nil schedule uses the frequency inferred from the times of the table.
+func (table *Compact[Value]) Quality(schedule Schedule) []ColumnQuality {
+	if table.isEmpty() {
+		return nil
+	}
+	if schedule == nil {
+		schedule = table.InferFrequency()
+	}
+	names := table.ColumnNames()
+	report := make([]ColumnQuality, 0, len(table.values))
+	for column, list := range table.Columns() {
+		quality := ColumnQuality{Column: names[column]}
+		times := observedTimes(list)
+		quality.Count, quality.Missing = len(times), len(list)-len(times)
+		if len(times) > 0 {
+			quality.First, quality.Last = times[0], times[len(times)-1]
+		}
+		for _, gap := range gaps(times, schedule) {
+			quality.MissingPeriods += gap.Periods
+			if gap.Periods > quality.LongestGap.Periods {
+				quality.LongestGap = gap
+			}
+		}
+		report = append(report, quality)
+	}
+	return report
+}
@@ -0,0 +1,102 @@
+package timetable_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/portfoliotree/timetable"
+)
+
+func TestList_Gaps(t *testing.T) {
+	list := List{elV(day3, 1), elV(day0, 1), timetable.NewMissingCell[Value](date(dayAfter))}
+	withHoliday, err := timetable.NewCalendar([]time.Weekday{time.Saturday, time.Sunday}, date(day1))
+	require.NoError(t, err)
+
+	for _, tt := range []struct {
+		Name     string
+		Schedule timetable.Schedule
+		Gaps     []timetable.Gap
+	}{
+		{
+			Name:     "business days",
+			Schedule: timetable.BusinessDaily,
+			Gaps:     []timetable.Gap{{Start: date(day1), End: date(day2), Periods: 2}},
+		},
+		{
+			Name:     "calendar days",
+			Schedule: timetable.Daily,
+			Gaps:     []timetable.Gap{{Start: date(day1), End: date(day2), Periods: 4}},
+		},
+		{
+			Name:     "calendar with a holiday",
+			Schedule: withHoliday,
+			Gaps:     []timetable.Gap{{Start: date(day2), End: date(day2), Periods: 1}},
+		},
+		{
+			Name:     "weeks",
+			Schedule: timetable.Weekly,
+		},
+		{
+			Name:     "irregular",
+			Schedule: timetable.Irregular,
+		},
+	} {
+		t.Run(tt.Name, func(t *testing.T) {
+			assert.Equal(t, tt.Gaps, list.Gaps(tt.Schedule))
+		})
+	}
+
+	t.Run("separate gaps", func(t *testing.T) {
+		list := List{elV("2022-01-31", 1), elV("2022-04-30", 1), elV("2022-05-31", 1), elV("2022-07-31", 1)}
+		assert.Equal(t, []timetable.Gap{
+			{Start: date("2022-02-01"), End: date("2022-03-01"), Periods: 2},
+			{Start: date("2022-06-01"), End: date("2022-06-01"), Periods: 1},
+		}, list.Gaps(timetable.Monthly))
+	})
+
+	assert.Nil(t, List{}.Gaps(timetable.Daily))
+}
+
+func TestCompact_Gaps(t *testing.T) {
+	table := timetable.New(List{elV(day0, 1), elV(day2, 3)}).
+		AddColumnWithJoin(List{elV(day0, 10), timetable.NewMissingCell[Value](date(day3)), elV(dayAfter, 50)}, nil, timetable.JoinOuter)
+	require.True(t, table.IsMissing(2, 0))
+	require.True(t, table.IsMissing(2, 1))
+	assert.Equal(t, []timetable.Gap{
+		{Start: date(day1), End: date(day1), Periods: 1},
+		{Start: date(day3), End: date(day3), Periods: 1},
+	}, table.Gaps(timetable.BusinessDaily))
+
+	var empty *Table
+	assert.Nil(t, empty.Gaps(timetable.BusinessDaily))
+}
+
+func TestCompact_Quality(t *testing.T) {
+	table := maskedTable()
+	require.NoError(t, table.SetColumnNames("a", "b"))
+
+	report := table.Quality(nil)
+	assert.Equal(t, []timetable.ColumnQuality{
+		{Column: "a", First: date(day0), Last: date(day3), Count: 4},
+		{
+			Column: "b", First: date(day0), Last: date(day3), Count: 2, Missing: 2, MissingPeriods: 2,
+			LongestGap: timetable.Gap{Start: date(day1), End: date(day2), Periods: 2},
+		},
+	}, report)
+
+	t.Run("another schedule", func(t *testing.T) {
+		report := table.Quality(timetable.Daily)
+		assert.Equal(t, 4, report[1].MissingPeriods)
+		assert.Equal(t, 2, report[0].MissingPeriods)
+		assert.Equal(t, 2, report[0].LongestGap.Periods)
+	})
+
+	t.Run("empty column", func(t *testing.T) {
+		table := timetable.New(List{elV(day0, 1)}).AddColumnWithJoin(List{timetable.NewMissingCell[Value](date(day0))}, nil, timetable.JoinLeft)
+		report := table.Quality(timetable.BusinessDaily)
+		assert.Equal(t, timetable.ColumnQuality{Missing: 1}, report[1])
+	})
+}
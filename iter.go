@@ -0,0 +1,169 @@
+package timetable
+
+import (
+	"iter"
+	"slices"
+	"time"
+)
+
+// RowView is a read-only view of one row of a table.
+// It reads through to the table, so it sees later changes to the table's values.
+type RowView[Value any] struct {
+	table *Compact[Value]
+	index int
+}
+
+func (row RowView[Value]) Time() time.Time { return row.table.times[row.index] }
+func (row RowView[Value]) Len() int        { return len(row.table.values) }
+
+// Value returns the value of the row in a column.
+func (row RowView[Value]) Value(column int) Value { return row.table.values[column][row.index] }
+
+// IsMissing reports whether the cell of the row in a column is missing.
+func (row RowView[Value]) IsMissing(column int) bool { return row.table.IsMissing(row.index, column) }
+
+// Values returns a copy of the values in the row.
+func (row RowView[Value]) Values() []Value { return row.table.row(row.index) }
+
+// Rows iterates over the rows of the table from the first time to the last.
+func (table *Compact[Value]) Rows() iter.Seq2[time.Time, RowView[Value]] {
+	return table.rows(0, table.NumberOfRows(), false)
+}
+
+// RowsBackward iterates over the rows of the table from the last time to the first.
+func (table *Compact[Value]) RowsBackward() iter.Seq2[time.Time, RowView[Value]] {
+	return table.rows(0, table.NumberOfRows(), true)
+}
+
+// RowsBetween iterates over the rows from t0 to t1 inclusive, in the same way as Between.
+func (table *Compact[Value]) RowsBetween(t0, t1 time.Time) iter.Seq2[time.Time, RowView[Value]] {
+	start, end := table.betweenIndexes(t0, t1)
+	return table.rows(start, end, false)
+}
+
+func (table *Compact[Value]) rows(start, end int, backward bool) iter.Seq2[time.Time, RowView[Value]] {
+	return func(yield func(time.Time, RowView[Value]) bool) {
+		for i := range end - start {
+			row := start + i
+			if backward {
+				row = end - 1 - i
+			}
+			if !yield(table.times[row], RowView[Value]{table: table, index: row}) {
+				return
+			}
+		}
+	}
+}
+
+// Columns iterates over the columns of the table in index order.
+// Each List is a new copy of the column.
+func (table *Compact[Value]) Columns() iter.Seq2[int, List[Value]] {
+	return table.columnsBetween(0, table.NumberOfRows(), false)
+}
+
+// ColumnsBackward iterates over the columns of the table from the last index to the first.
+func (table *Compact[Value]) ColumnsBackward() iter.Seq2[int, List[Value]] {
+	return table.columnsBetween(0, table.NumberOfRows(), true)
+}
+
+// ColumnsBetween iterates over the columns of the table, limiting each List to the cells from t0 to t1 inclusive.
+func (table *Compact[Value]) ColumnsBetween(t0, t1 time.Time) iter.Seq2[int, List[Value]] {
+	start, end := table.betweenIndexes(t0, t1)
+	return table.columnsBetween(start, end, false)
+}
+
+func (table *Compact[Value]) columnsBetween(start, end int, backward bool) iter.Seq2[int, List[Value]] {
+	return func(yield func(int, List[Value]) bool) {
+		for i := range table.values {
+			column := i
+			if backward {
+				column = len(table.values) - 1 - i
+			}
+			list := make(List[Value], end-start)
+			for row := range list {
+				list[row] = table.cell(start+row, column)
+			}
+			if !yield(column, list) {
+				return
+			}
+		}
+	}
+}
+
+// Cells iterates over every cell of the table along with its column index.
+// Cells are visited one column at a time, from the first time to the last.
+func (table *Compact[Value]) Cells() iter.Seq2[int, Cell[Value]] {
+	return table.cells(0, table.NumberOfRows(), false)
+}
+
+// CellsBackward visits the cells in the reverse of the order used by Cells.
+func (table *Compact[Value]) CellsBackward() iter.Seq2[int, Cell[Value]] {
+	return table.cells(0, table.NumberOfRows(), true)
+}
+
+// CellsBetween is like Cells but only visits the cells from t0 to t1 inclusive.
+func (table *Compact[Value]) CellsBetween(t0, t1 time.Time) iter.Seq2[int, Cell[Value]] {
+	start, end := table.betweenIndexes(t0, t1)
+	return table.cells(start, end, false)
+}
+
+func (table *Compact[Value]) cells(start, end int, backward bool) iter.Seq2[int, Cell[Value]] {
+	return func(yield func(int, Cell[Value]) bool) {
+		rows, columns := end-start, len(table.values)
+		for i := range rows * columns {
+			if backward {
+				i = rows*columns - 1 - i
+			}
+			column, row := i/rows, start+i%rows
+			if !yield(column, table.cell(row, column)) {
+				return
+			}
+		}
+	}
+}
+
+func (table *Compact[Value]) betweenIndexes(t0, t1 time.Time) (int, int) {
+	if table.isEmpty() || len(table.times) == 0 {
+		return 0, 0
+	}
+	return betweenIndexes(table.times, t0, t1, time.Time.Compare)
+}
+
+// All iterates over the cells of the list in index order.
+func (list List[Value]) All() iter.Seq2[int, Cell[Value]] {
+	return func(yield func(int, Cell[Value]) bool) {
+		for i, cell := range list {
+			if !yield(i, cell) {
+				return
+			}
+		}
+	}
+}
+
+// Backward iterates over the cells of the list from the last index to the first.
+func (list List[Value]) Backward() iter.Seq2[int, Cell[Value]] {
+	return func(yield func(int, Cell[Value]) bool) {
+		for i := len(list) - 1; i >= 0; i-- {
+			if !yield(i, list[i]) {
+				return
+			}
+		}
+	}
+}
+
+// AllBetween iterates over the cells from t0 to t1 inclusive.
+// Like Between, it sorts the list in place first; the yielded index is the index in the sorted list.
+func (list List[Value]) AllBetween(t0, t1 time.Time) iter.Seq2[int, Cell[Value]] {
+	return func(yield func(int, Cell[Value]) bool) {
+		if len(list) == 0 {
+			return
+		}
+		slices.SortFunc(list, Cell[Value].compareTimes)
+		start, end := betweenIndexes(list, t0, t1, Cell[Value].compareTime)
+		for i := start; i < end; i++ {
+			if !yield(i, list[i]) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,133 @@
+package timetable_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/portfoliotree/timetable"
+)
+
+func iterTable() *Table {
+	return timetable.New(
+		List{elV(day0, 1), elV(day1, 2), elV(day2, 3)},
+		List{elV(day0, 10), elV(day1, 20), elV(day2, 30)},
+	)
+}
+
+func TestCompact_Rows(t *testing.T) {
+	table := iterTable()
+
+	var (
+		times []time.Time
+		rows  [][]Value
+	)
+	for rowTime, row := range table.Rows() {
+		assert.Equal(t, rowTime, row.Time())
+		assert.Equal(t, 2, row.Len())
+		times = append(times, rowTime)
+		rows = append(rows, []Value{row.Value(0), row.Value(1)})
+	}
+	assert.Equal(t, []time.Time{date(day0), date(day1), date(day2)}, times)
+	assert.Equal(t, [][]Value{{1, 10}, {2, 20}, {3, 30}}, rows)
+
+	times = times[:0]
+	for rowTime := range table.RowsBackward() {
+		times = append(times, rowTime)
+	}
+	assert.Equal(t, []time.Time{date(day2), date(day1), date(day0)}, times)
+
+	rows = rows[:0]
+	for _, row := range table.RowsBetween(date(dayAfter), date(day1)) {
+		rows = append(rows, row.Values())
+	}
+	assert.Equal(t, [][]Value{{2, 20}, {3, 30}}, rows)
+
+	for range table.Rows() {
+		break
+	}
+	for range timetable.New[Value]().RowsBetween(date(day0), date(day1)) {
+		t.Fatal("empty tables have no rows")
+	}
+}
+
+func TestCompact_Columns(t *testing.T) {
+	table := iterTable()
+
+	var indexes []int
+	for column, list := range table.Columns() {
+		indexes = append(indexes, column)
+		expected, _ := table.Column(column)
+		assert.Equal(t, expected, list)
+	}
+	assert.Equal(t, []int{0, 1}, indexes)
+
+	indexes = indexes[:0]
+	for column := range table.ColumnsBackward() {
+		indexes = append(indexes, column)
+	}
+	assert.Equal(t, []int{1, 0}, indexes)
+
+	var lists []List
+	for _, list := range table.ColumnsBetween(date(day0), date(day0)) {
+		lists = append(lists, list)
+	}
+	assert.Equal(t, []List{{elV(day0, 1)}, {elV(day0, 10)}}, lists)
+}
+
+func TestCompact_Cells(t *testing.T) {
+	table := iterTable()
+
+	var (
+		columns []int
+		cells   List
+	)
+	for column, cell := range table.Cells() {
+		columns = append(columns, column)
+		cells = append(cells, cell)
+	}
+	assert.Equal(t, []int{0, 0, 0, 1, 1, 1}, columns)
+	assert.Equal(t, List{elV(day0, 1), elV(day1, 2), elV(day2, 3), elV(day0, 10), elV(day1, 20), elV(day2, 30)}, cells)
+
+	columns, cells = columns[:0], cells[:0]
+	for column, cell := range table.CellsBackward() {
+		columns = append(columns, column)
+		cells = append(cells, cell)
+	}
+	assert.Equal(t, []int{1, 1, 1, 0, 0, 0}, columns)
+	assert.Equal(t, List{elV(day2, 30), elV(day1, 20), elV(day0, 10), elV(day2, 3), elV(day1, 2), elV(day0, 1)}, cells)
+
+	cells = cells[:0]
+	for _, cell := range table.CellsBetween(date(day1), date(day1)) {
+		cells = append(cells, cell)
+	}
+	assert.Equal(t, List{elV(day1, 2), elV(day1, 20)}, cells)
+}
+
+func TestList_All(t *testing.T) {
+	list := List{elV(day1, 2), elV(day0, 1), elV(day2, 3)}
+
+	var cells List
+	for i, cell := range list.All() {
+		assert.Equal(t, list[i], cell)
+		cells = append(cells, cell)
+	}
+	assert.Equal(t, List{elV(day1, 2), elV(day0, 1), elV(day2, 3)}, cells)
+
+	cells = cells[:0]
+	for _, cell := range list.Backward() {
+		cells = append(cells, cell)
+	}
+	assert.Equal(t, List{elV(day2, 3), elV(day0, 1), elV(day1, 2)}, cells)
+
+	cells = cells[:0]
+	for _, cell := range list.AllBetween(date(day1), date(dayAfter)) {
+		cells = append(cells, cell)
+	}
+	assert.Equal(t, List{elV(day1, 2), elV(day2, 3)}, cells)
+
+	for range List(nil).AllBetween(date(day0), date(day1)) {
+		t.Fatal("nil lists have no cells")
+	}
+}
@@ -0,0 +1,338 @@
+package timetable
+
+import (
+	"math/bits"
+	"slices"
+	"time"
+)
+
+// JoinMode selects which timestamps are kept when a list is added to a table.
+type JoinMode int
+
+const (
+	// JoinOverlap cuts the new list down to the time range of the table,
+	// then cuts the table down to the time range of what is left of the list,
+	// and keeps every timestamp from either side inside that range.
+	JoinOverlap JoinMode = iota
+
+	// JoinInner keeps only the timestamps present in both the table and the new list.
+	JoinInner
+
+	// JoinOuter keeps every timestamp from the table and the new list.
+	JoinOuter
+
+	// JoinLeft keeps the timestamps of the table.
+	JoinLeft
+
+	// JoinRight keeps the timestamps of the new list.
+	JoinRight
+)
+
+func (join JoinMode) String() string {
+	switch join {
+	case JoinOverlap:
+		return "overlap"
+	case JoinInner:
+		return "inner"
+	case JoinOuter:
+		return "outer"
+	case JoinLeft:
+		return "left"
+	case JoinRight:
+		return "right"
+	default:
+		return "unknown"
+	}
+}
+
+// AddColumnWithJoin adds list as a new column keeping the timestamps selected by join.
+// The missing function is called for each cell that neither the table nor the list has a value for.
+// When missing is nil those cells are marked missing instead. Missing cells of the table and list stay missing.
+func (table *Compact[Value]) AddColumnWithJoin(list List[Value], missing func(time.Time, int) Value, join JoinMode) *Compact[Value] {
+	return table.addColumn(list, missing, join, ColumnInfo{})
+}
+
+// joinColumns returns the table with lists added as new columns, keeping the same times
+// as adding the lists one at a time with join. It also returns, for each column of the result,
+// the row each cell was copied from or -1 for cells filled by missing.
+// A table without times is treated as having no columns, so the first list becomes the table lists are joined onto.
+func (table *Compact[Value]) joinColumns(lists []List[Value], missing func(time.Time, int) Value, join JoinMode) (*Compact[Value], []rowMapping) {
+	maskGaps := missing == nil
+	if maskGaps {
+		missing = zeroValue[Value]
+	}
+	sources := make([]List[Value], len(lists))
+	for i, list := range lists {
+		sources[i] = sortedList(list)
+	}
+
+	var (
+		anchor   []time.Time
+		existing [][]Value
+		joined   = sources
+	)
+	switch {
+	case !table.isEmpty():
+		anchor, existing = table.times, table.values
+	case len(sources) > 0:
+		anchor, joined = uniqueTimes(sources[0]), sources[1:]
+	default:
+		return new(Compact[Value]), nil
+	}
+	times := joinTimes(anchor, joined, join)
+
+	mappings := make([]rowMapping, len(existing)+len(sources))
+	if len(existing) > 0 {
+		mapping := mapRows(times, anchor, time.Time.Compare)
+		for column := range existing {
+			mappings[column] = mapping
+		}
+	}
+	for i, source := range sources {
+		mappings[len(existing)+i] = mapRows(times, source, Cell[Value].compareTime)
+	}
+	if join == JoinRight {
+		dropReplacedRows(mappings, len(existing))
+	}
+
+	values := make([][]Value, len(mappings))
+	masks := make([][]bool, len(mappings))
+	for column, mapping := range mappings {
+		if column < len(existing) {
+			values[column] = remap(mapping, times, column, missing, func(row int) Value { return existing[column][row] })
+			masks[column] = remapMask(mapping, maskGaps, func(row int) bool { return table.IsMissing(row, column) })
+			continue
+		}
+		source := sources[column-len(existing)]
+		values[column] = remap(mapping, times, column, missing, func(index int) Value { return source[index].value })
+		masks[column] = remapMask(mapping, maskGaps, func(index int) bool { return source[index].missing })
+	}
+	return &Compact[Value]{times: times, values: values, missing: compactMask(masks)}, mappings
+}
+
+// dropReplacedRows matches adding lists one at a time with JoinRight, where each list replaces the times
+// of the table: a cell only survives when every list added after it has its time.
+// The first existing columns all come from the table the lists are joined onto.
+func dropReplacedRows(mappings []rowMapping, existing int) {
+	if len(mappings) == 0 {
+		return
+	}
+	kept := make([]bool, len(mappings[0]))
+	for row := range kept {
+		kept[row] = true
+	}
+	mask := func(mapping rowMapping) rowMapping {
+		masked := slices.Clone(mapping)
+		for row, index := range masked {
+			if !kept[row] {
+				masked[row] = -1
+			}
+			kept[row] = kept[row] && index >= 0
+		}
+		return masked
+	}
+	for column := len(mappings) - 1; column >= existing; column-- {
+		mappings[column] = mask(mappings[column])
+	}
+	if existing > 0 {
+		mapping := mask(mappings[0])
+		for column := range existing {
+			mappings[column] = mapping
+		}
+	}
+}
+
+func sortedList[Value any](list List[Value]) List[Value] {
+	if slices.IsSortedFunc(list, Cell[Value].compareTimes) {
+		return list
+	}
+	list = slices.Clone(list)
+	slices.SortStableFunc(list, Cell[Value].compareTimes)
+	return list
+}
+
+func uniqueTimes[Value any](list List[Value]) []time.Time {
+	times := make([]time.Time, 0, len(list))
+	for i, cell := range list {
+		if i == 0 || !cell.time.Equal(list[i-1].time) {
+			times = append(times, cell.time)
+		}
+	}
+	return slices.Clip(times)
+}
+
+// rowMapping holds, for each row of a joined table, the index of the source row or -1 when the source has no such row.
+type rowMapping []int
+
+// mapRows walks sorted times and a sorted source once, matching each time to the first source element at that time.
+func mapRows[E any](times []time.Time, source []E, cmp func(E, time.Time) int) rowMapping {
+	mapping := make(rowMapping, len(times))
+	j := 0
+	for row, t := range times {
+		for j < len(source) && cmp(source[j], t) < 0 {
+			j++
+		}
+		if j < len(source) && cmp(source[j], t) == 0 {
+			mapping[row] = j
+		} else {
+			mapping[row] = -1
+		}
+	}
+	return mapping
+}
+
+func remap[Value any](mapping rowMapping, times []time.Time, column int, missing func(time.Time, int) Value, value func(int) Value) []Value {
+	result := make([]Value, len(mapping))
+	for row, index := range mapping {
+		if index >= 0 {
+			result[row] = value(index)
+		} else {
+			result[row] = missing(times[row], column)
+		}
+	}
+	return result
+}
+
+// remapMask returns the missing cells of a column after a join. A cell is missing when its source cell is missing
+// or, if maskGaps is set, when the source has no cell at its time. It returns nil when no cell is missing.
+func remapMask(mapping rowMapping, maskGaps bool, missing func(int) bool) []bool {
+	var mask []bool
+	for row, index := range mapping {
+		if (index < 0 && maskGaps) || (index >= 0 && missing(index)) {
+			if mask == nil {
+				mask = make([]bool, len(mapping))
+			}
+			mask[row] = true
+		}
+	}
+	return mask
+}
+
+// joinTimes returns the times of a table with the given times after adding each of the sorted lists in turn.
+// The result is never nil.
+func joinTimes[Value any](anchor []time.Time, lists []List[Value], join JoinMode) []time.Time {
+	switch join {
+	case JoinLeft:
+		return slices.Clone(anchor)[:len(anchor):len(anchor)]
+	case JoinRight:
+		if len(lists) == 0 {
+			return slices.Clone(anchor)[:len(anchor):len(anchor)]
+		}
+		return uniqueTimes(lists[len(lists)-1])
+	case JoinInner:
+		times := slices.Clone(anchor)[:len(anchor):len(anchor)]
+		for _, list := range lists {
+			mapping := mapRows(times, list, Cell[Value].compareTime)
+			kept := times[:0]
+			for row, index := range mapping {
+				if index >= 0 {
+					kept = append(kept, times[row])
+				}
+			}
+			times = slices.Clip(kept)
+		}
+		return times
+	case JoinOuter:
+		return allTimes(anchor, lists).times
+	default:
+		return overlapTimes(anchor, lists)
+	}
+}
+
+// sourcedTimes holds the sorted unique times of a table and several lists.
+// For each time it also holds the index of the first source with that time, where the table is source 0
+// and list i is source i+1.
+type sourcedTimes struct {
+	times  []time.Time
+	source []int
+}
+
+func allTimes[Value any](anchor []time.Time, lists []List[Value]) sourcedTimes {
+	result := sourcedTimes{
+		times:  slices.Clone(anchor),
+		source: make([]int, len(anchor)),
+	}
+	for i, list := range lists {
+		merged := sourcedTimes{
+			times:  make([]time.Time, 0, len(result.times)+len(list)),
+			source: make([]int, 0, len(result.times)+len(list)),
+		}
+		emit := func(t time.Time, source int) {
+			merged.times = append(merged.times, t)
+			merged.source = append(merged.source, source)
+		}
+		k, j := 0, 0
+		for k < len(result.times) || j < len(list) {
+			if j > 0 && j < len(list) && list[j].time.Equal(list[j-1].time) {
+				j++
+				continue
+			}
+			switch {
+			case j == len(list) || (k < len(result.times) && !list[j].time.Before(result.times[k])):
+				if j < len(list) && list[j].time.Equal(result.times[k]) {
+					j++
+				}
+				emit(result.times[k], result.source[k])
+				k++
+			default:
+				emit(list[j].time, i+1)
+				j++
+			}
+		}
+		result = merged
+	}
+	result.times = slices.Clip(result.times)
+	return result
+}
+
+// overlapTimes repeats the range cutting of JoinOverlap for each list without building the intermediate tables.
+// Before each list is added, the table holds every time of the earlier sources inside the current range.
+func overlapTimes[Value any](anchor []time.Time, lists []List[Value]) []time.Time {
+	if len(anchor) == 0 {
+		return []time.Time{}
+	}
+	all := allTimes(anchor, lists)
+	earliest := newRangeMinimum(all.source)
+	t0, t1 := anchor[0], anchor[len(anchor)-1]
+	for i, list := range lists {
+		if len(list) == 0 {
+			return []time.Time{}
+		}
+		first, last := betweenIndexes(list, t0, t1, Cell[Value].compareTime)
+		if first == last {
+			return []time.Time{}
+		}
+		c0, c1 := list[first].time, list[last-1].time
+		start, end := betweenIndexes(all.times, c0, c1, time.Time.Compare)
+		if earliest.query(start, end) > i {
+			return []time.Time{}
+		}
+		t0, t1 = c0, c1
+	}
+	start, end := betweenIndexes(all.times, t0, t1, time.Time.Compare)
+	return all.times[start:end:end]
+}
+
+// rangeMinimum answers minimum queries over ranges of a fixed slice in constant time.
+type rangeMinimum struct {
+	levels [][]int
+}
+
+func newRangeMinimum(values []int) rangeMinimum {
+	levels := [][]int{values}
+	for width := 2; width <= len(values); width *= 2 {
+		previous := levels[len(levels)-1]
+		level := make([]int, len(values)-width+1)
+		for i := range level {
+			level[i] = min(previous[i], previous[i+width/2])
+		}
+		levels = append(levels, level)
+	}
+	return rangeMinimum{levels: levels}
+}
+
+// query returns the minimum of values[start:end]. The range must not be empty.
+func (r rangeMinimum) query(start, end int) int {
+	level := bits.Len(uint(end-start)) - 1
+	return min(r.levels[level][start], r.levels[level][end-(1<<level)])
+}
@@ -0,0 +1,97 @@
+package timetable_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/portfoliotree/timetable"
+)
+
+func missingNegativeOne(time.Time, int) Value { return -1 }
+
+func TestCompact_AddColumnWithJoin(t *testing.T) {
+	existing := List{elV(day0, 1), elV(day1, 2), elV(day3, 4)}
+	short := List{elV(day1, 20), elV(day2, 30)}
+
+	for _, tt := range []struct {
+		Join   timetable.JoinMode
+		Times  []time.Time
+		Values [][]Value
+	}{
+		{
+			Join:   timetable.JoinOverlap,
+			Times:  []time.Time{date(day1), date(day2)},
+			Values: [][]Value{{2, -1}, {20, 30}},
+		},
+		{
+			Join:   timetable.JoinInner,
+			Times:  []time.Time{date(day1)},
+			Values: [][]Value{{2}, {20}},
+		},
+		{
+			Join:   timetable.JoinOuter,
+			Times:  []time.Time{date(day0), date(day1), date(day2), date(day3)},
+			Values: [][]Value{{1, 2, -1, 4}, {-1, 20, 30, -1}},
+		},
+		{
+			Join:   timetable.JoinLeft,
+			Times:  []time.Time{date(day0), date(day1), date(day3)},
+			Values: [][]Value{{1, 2, 4}, {-1, 20, -1}},
+		},
+		{
+			Join:   timetable.JoinRight,
+			Times:  []time.Time{date(day1), date(day2)},
+			Values: [][]Value{{2, -1}, {20, 30}},
+		},
+	} {
+		t.Run(tt.Join.String(), func(t *testing.T) {
+			table := timetable.New(existing)
+			result := table.AddColumnWithJoin(short, missingNegativeOne, tt.Join)
+			assert.Equal(t, tt.Times, result.Times())
+			assert.Equal(t, tt.Values, result.Values())
+			assert.Equal(t, [][]Value{{1, 2, 4}}, table.Values(), "it does not modify the table")
+		})
+	}
+
+	t.Run("outer join on an empty table", func(t *testing.T) {
+		var table *Table
+		result := table.AddColumnWithJoin(short, missingNegativeOne, timetable.JoinOuter)
+		assert.Equal(t, [][]Value{{20, 30}}, result.Values())
+	})
+
+	t.Run("right join on a table without rows", func(t *testing.T) {
+		table := timetable.New(List{})
+		result := table.AddColumnWithJoin(short, missingNegativeOne, timetable.JoinRight)
+		assert.Equal(t, []time.Time{date(day1), date(day2)}, result.Times())
+		assert.Equal(t, [][]Value{{-1, -1}, {20, 30}}, result.Values())
+	})
+
+	t.Run("inner join without overlap", func(t *testing.T) {
+		table := timetable.New(List{elV(day0, 1)})
+		result := table.AddColumnWithJoin(List{elV(day1, 2)}, missingNegativeOne, timetable.JoinInner)
+		assert.Equal(t, 0, result.NumberOfRows())
+		assert.Equal(t, 2, result.NumberOfColumns())
+	})
+
+	t.Run("overlap uses the range of the list cells inside the table", func(t *testing.T) {
+		table := timetable.New(List{elV(day0, 1), elV(day1, 2), elV(day2, 3), elV(day3, 4)})
+		result := table.AddColumnWithJoin(List{elV(dayBefore, 10), elV(day2, 30), elV(dayAfter, 50)}, missingNegativeOne, timetable.JoinOverlap)
+		assert.Equal(t, []time.Time{date(day2)}, result.Times())
+		assert.Equal(t, [][]Value{{3}, {30}}, result.Values())
+	})
+
+	t.Run("it does not sort the list in place", func(t *testing.T) {
+		list := List{elV(day1, 2), elV(day0, 1)}
+		_ = timetable.New(list)
+		assert.Equal(t, List{elV(day1, 2), elV(day0, 1)}, list)
+	})
+
+	t.Run("unsorted list with duplicate times", func(t *testing.T) {
+		table := timetable.New(List{elV(day0, 1), elV(day1, 2)})
+		result := table.AddColumnWithJoin(List{elV(day2, 3), elV(day1, 2), elV(day2, 3)}, missingNegativeOne, timetable.JoinRight)
+		assert.Equal(t, []time.Time{date(day1), date(day2)}, result.Times())
+		assert.Equal(t, [][]Value{{2, -1}, {2, 3}}, result.Values())
+	})
+}
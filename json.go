@@ -0,0 +1,246 @@
+package timetable
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+)
+
+type cellJSON[Value any] struct {
+	Time  time.Time        `json:"time"`
+	Value jsonValue[Value] `json:"value"`
+}
+
+// jsonValue encodes a missing value as null.
+type jsonValue[Value any] struct {
+	value   Value
+	missing bool
+}
+
+func (v jsonValue[Value]) MarshalJSON() ([]byte, error) {
+	if v.missing {
+		return []byte("null"), nil
+	}
+	return json.Marshal(v.value)
+}
+
+func (v *jsonValue[Value]) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*v = jsonValue[Value]{missing: true}
+		return nil
+	}
+	return json.Unmarshal(data, &v.value)
+}
+
+// MarshalJSON encodes the cell as {"time": "2006-01-02T15:04:05Z", "value": value}.
+// The value of a missing cell is null.
+func (c Cell[Value]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(cellJSON[Value]{Time: c.time, Value: jsonValue[Value]{value: c.value, missing: c.missing}})
+}
+
+// UnmarshalJSON decodes the format written by MarshalJSON. A null value gives a missing cell.
+func (c *Cell[Value]) UnmarshalJSON(data []byte) error {
+	var decoded cellJSON[Value]
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+	*c = Cell[Value]{time: decoded.Time, value: decoded.Value.value, missing: decoded.Value.missing}
+	return nil
+}
+
+// MarshalJSON encodes the list as an array of cells. A nil list is encoded as an empty array.
+func (list List[Value]) MarshalJSON() ([]byte, error) {
+	if list == nil {
+		return []byte("[]"), nil
+	}
+	return json.Marshal([]Cell[Value](list))
+}
+
+// UnmarshalJSON decodes an array of cells.
+func (list *List[Value]) UnmarshalJSON(data []byte) error {
+	var cells []Cell[Value]
+	if err := json.Unmarshal(data, &cells); err != nil {
+		return err
+	}
+	*list = cells
+	return nil
+}
+
+type compactJSON[Value any] struct {
+	Columns []ColumnInfo         `json:"columns,omitempty"`
+	Times   []time.Time          `json:"times,omitempty"`
+	Values  [][]jsonValue[Value] `json:"values,omitempty"`
+	Rows    []rowJSON[Value]     `json:"rows,omitempty"`
+}
+
+type rowJSON[Value any] struct {
+	Time   time.Time          `json:"time"`
+	Values []jsonValue[Value] `json:"values"`
+}
+
+// MarshalJSON encodes the table in a column-oriented layout:
+//
+//	{"columns": [{"name": "AAA"}], "times": ["2022-10-20T00:00:00Z"], "values": [[1]]}
+//
+// The columns field is left out when no column has a name or metadata. Missing cells are null.
+// Use JSONRows for a row-oriented layout.
+func (table *Compact[Value]) MarshalJSON() ([]byte, error) {
+	times := table.times
+	if times == nil {
+		times = []time.Time{}
+	}
+	var values any = table.values
+	switch {
+	case table.values == nil:
+		values = [][]Value{}
+	case table.HasMissing():
+		encoded := make([][]jsonValue[Value], len(table.values))
+		for column := range encoded {
+			encoded[column] = make([]jsonValue[Value], len(times))
+			for row := range encoded[column] {
+				encoded[column][row] = table.jsonValue(row, column)
+			}
+		}
+		values = encoded
+	}
+	return json.Marshal(struct {
+		Columns []ColumnInfo `json:"columns,omitempty"`
+		Times   []time.Time  `json:"times"`
+		Values  any          `json:"values"`
+	}{Columns: table.jsonColumns(), Times: times, Values: values})
+}
+
+func (table *Compact[Value]) jsonValue(row, column int) jsonValue[Value] {
+	return jsonValue[Value]{value: table.values[column][row], missing: table.IsMissing(row, column)}
+}
+
+// UnmarshalJSON decodes either layout written by MarshalJSON or JSONRows. Null values give missing cells.
+// It returns an error when the times are not strictly increasing, a column has a different length than the times
+// or a row has a different number of values than the first row.
+func (table *Compact[Value]) UnmarshalJSON(data []byte) error {
+	var decoded compactJSON[Value]
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+	times, values := decoded.Times, decoded.Values
+	if decoded.Rows != nil {
+		if times != nil || values != nil {
+			return errors.New("table JSON has both rows and times or values")
+		}
+		var err error
+		if times, values, err = decoded.rowsToColumns(); err != nil {
+			return err
+		}
+	}
+	if decoded.Columns != nil && len(decoded.Columns) != len(values) {
+		return fmt.Errorf("table JSON has %d column descriptions for %d columns", len(decoded.Columns), len(values))
+	}
+	for i := 1; i < len(times); i++ {
+		if !times[i-1].Before(times[i]) {
+			return fmt.Errorf("table JSON times are not strictly increasing at index %d", i)
+		}
+	}
+	for column := range values {
+		if len(values[column]) != len(times) {
+			return fmt.Errorf("table JSON column %d has %d values for %d times", column, len(values[column]), len(times))
+		}
+	}
+	names := make([]string, len(decoded.Columns))
+	for i, info := range decoded.Columns {
+		names[i] = info.Name
+	}
+	if err := checkDuplicateNames(names); err != nil {
+		return err
+	}
+	if len(times) == 0 && len(values) == 0 {
+		times = nil
+	} else if times == nil {
+		times = []time.Time{}
+	}
+	columns := decoded.Columns
+	if !slices.ContainsFunc(columns, func(info ColumnInfo) bool { return !info.isZero() }) {
+		columns = nil
+	}
+	result := Compact[Value]{times: times, values: make([][]Value, len(values)), columns: columns}
+	for column, encoded := range values {
+		result.values[column] = make([]Value, len(encoded))
+		for row, value := range encoded {
+			result.values[column][row] = value.value
+			if value.missing {
+				result.setMissing(row, column, true)
+			}
+		}
+	}
+	*table = result
+	return nil
+}
+
+func (decoded compactJSON[Value]) rowsToColumns() ([]time.Time, [][]jsonValue[Value], error) {
+	numberOfColumns := len(decoded.Columns)
+	if len(decoded.Rows) > 0 {
+		numberOfColumns = len(decoded.Rows[0].Values)
+	}
+	times := make([]time.Time, len(decoded.Rows))
+	values := make([][]jsonValue[Value], numberOfColumns)
+	for column := range values {
+		values[column] = make([]jsonValue[Value], 0, len(decoded.Rows))
+	}
+	for i, row := range decoded.Rows {
+		if len(row.Values) != numberOfColumns {
+			return nil, nil, fmt.Errorf("table JSON row %d has %d values for %d columns", i, len(row.Values), numberOfColumns)
+		}
+		times[i] = row.Time
+		for column, value := range row.Values {
+			values[column] = append(values[column], value)
+		}
+	}
+	return times, values, nil
+}
+
+func (table *Compact[Value]) jsonColumns() []ColumnInfo {
+	if len(table.columns) == 0 {
+		return nil
+	}
+	return table.columnInfos()
+}
+
+// JSONRows wraps a table so it is encoded in a row-oriented layout:
+//
+//	{"columns": [{"name": "AAA"}], "rows": [{"time": "2022-10-20T00:00:00Z", "values": [1]}]}
+//
+// The columns are always written, one per column, so a table without rows keeps its number of columns.
+type JSONRows[Value any] struct {
+	Table *Compact[Value]
+}
+
+// JSONRows returns the table wrapped for row-oriented JSON encoding.
+func (table *Compact[Value]) JSONRows() JSONRows[Value] { return JSONRows[Value]{Table: table} }
+
+// MarshalJSON encodes the wrapped table with one object per row.
+func (rows JSONRows[Value]) MarshalJSON() ([]byte, error) {
+	table := rows.Table
+	encoded := make([]rowJSON[Value], len(table.times))
+	for row, t := range table.times {
+		values := make([]jsonValue[Value], len(table.values))
+		for column := range values {
+			values[column] = table.jsonValue(row, column)
+		}
+		encoded[row] = rowJSON[Value]{Time: t, Values: values}
+	}
+	return json.Marshal(struct {
+		Columns []ColumnInfo     `json:"columns,omitempty"`
+		Rows    []rowJSON[Value] `json:"rows"`
+	}{Columns: table.columnInfos(), Rows: encoded})
+}
+
+// UnmarshalJSON decodes either layout into a new table.
+func (rows *JSONRows[Value]) UnmarshalJSON(data []byte) error {
+	table := new(Compact[Value])
+	if err := table.UnmarshalJSON(data); err != nil {
+		return err
+	}
+	rows.Table = table
+	return nil
+}
@@ -0,0 +1,173 @@
+package timetable_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/portfoliotree/timetable"
+)
+
+func TestCell_JSON(t *testing.T) {
+	buf, err := json.Marshal(elV(day0, 1))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"time":"2022-10-20T00:00:00Z","value":1}`, string(buf))
+
+	var cell Cell
+	require.NoError(t, json.Unmarshal(buf, &cell))
+	assert.Equal(t, elV(day0, 1), cell)
+
+	t.Run("missing", func(t *testing.T) {
+		buf, err := json.Marshal(timetable.NewMissingCell[Value](date(day0)))
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"time":"2022-10-20T00:00:00Z","value":null}`, string(buf))
+
+		var cell Cell
+		require.NoError(t, json.Unmarshal(buf, &cell))
+		assert.True(t, cell.IsMissing())
+	})
+}
+
+func TestList_JSON(t *testing.T) {
+	buf, err := json.Marshal(List{elV(day0, 1), elV(day1, 2)})
+	require.NoError(t, err)
+	assert.JSONEq(t, `[{"time":"2022-10-20T00:00:00Z","value":1},{"time":"2022-10-21T00:00:00Z","value":2}]`, string(buf))
+
+	var list List
+	require.NoError(t, json.Unmarshal(buf, &list))
+	assert.Equal(t, List{elV(day0, 1), elV(day1, 2)}, list)
+
+	buf, err = json.Marshal(List(nil))
+	require.NoError(t, err)
+	assert.Equal(t, `[]`, string(buf))
+}
+
+func TestCompact_JSON(t *testing.T) {
+	table, err := timetable.NewNamed([]string{"AAA", "BBB"},
+		List{elV(day0, 1), elV(day1, 2)},
+		List{elV(day0, 10), elV(day1, 20)},
+	)
+	require.NoError(t, err)
+
+	t.Run("columns", func(t *testing.T) {
+		buf, err := json.Marshal(table)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{
+			"columns": [{"name": "AAA"}, {"name": "BBB"}],
+			"times": ["2022-10-20T00:00:00Z", "2022-10-21T00:00:00Z"],
+			"values": [[1, 2], [10, 20]]
+		}`, string(buf))
+
+		var decoded Table
+		require.NoError(t, json.Unmarshal(buf, &decoded))
+		assert.Equal(t, table.ColumnNames(), decoded.ColumnNames())
+		assert.Equal(t, table.Times(), decoded.Times())
+		assert.Equal(t, table.Values(), decoded.Values())
+	})
+
+	t.Run("rows", func(t *testing.T) {
+		buf, err := json.Marshal(table.JSONRows())
+		require.NoError(t, err)
+		assert.JSONEq(t, `{
+			"columns": [{"name": "AAA"}, {"name": "BBB"}],
+			"rows": [
+				{"time": "2022-10-20T00:00:00Z", "values": [1, 10]},
+				{"time": "2022-10-21T00:00:00Z", "values": [2, 20]}
+			]
+		}`, string(buf))
+
+		var decoded timetable.JSONRows[Value]
+		require.NoError(t, json.Unmarshal(buf, &decoded))
+		assert.Equal(t, table.ColumnNames(), decoded.Table.ColumnNames())
+		assert.Equal(t, table.Times(), decoded.Table.Times())
+		assert.Equal(t, table.Values(), decoded.Table.Values())
+	})
+
+	t.Run("missing cells are null", func(t *testing.T) {
+		masked := table.AddColumnWithJoin(List{elV(day1, 30)}, nil, timetable.JoinLeft)
+		buf, err := json.Marshal(masked)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{
+			"columns": [{"name": "AAA"}, {"name": "BBB"}, {}],
+			"times": ["2022-10-20T00:00:00Z", "2022-10-21T00:00:00Z"],
+			"values": [[1, 2], [10, 20], [null, 30]]
+		}`, string(buf))
+
+		var decoded Table
+		require.NoError(t, json.Unmarshal(buf, &decoded))
+		assert.True(t, decoded.IsMissing(0, 2))
+		assert.False(t, decoded.IsMissing(1, 2))
+
+		buf, err = json.Marshal(masked.JSONRows())
+		require.NoError(t, err)
+		assert.Contains(t, string(buf), `"values":[1,10,null]`)
+		require.NoError(t, json.Unmarshal(buf, &decoded))
+		assert.True(t, decoded.IsMissing(0, 2))
+	})
+
+	t.Run("rows of a table without rows", func(t *testing.T) {
+		unnamed := timetable.New(List{elV(day0, 1)}, List{elV(day0, 10)})
+		require.Equal(t, 1, unnamed.DeleteRowsBetween(date(day0), date(day0)))
+		buf, err := json.Marshal(unnamed.JSONRows())
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"columns":[{},{}],"rows":[]}`, string(buf))
+
+		var decoded timetable.JSONRows[Value]
+		require.NoError(t, json.Unmarshal(buf, &decoded))
+		assert.Equal(t, 2, decoded.Table.NumberOfColumns())
+		assert.Equal(t, 0, decoded.Table.NumberOfRows())
+		require.NoError(t, decoded.Table.AppendRow(date(day1), 2, 20))
+		assert.Equal(t, [][]Value{{2}, {20}}, decoded.Table.Values())
+
+		buf, err = json.Marshal(decoded.Table)
+		require.NoError(t, err)
+		assert.NotContains(t, string(buf), `"columns"`, "unnamed columns stay unnamed")
+	})
+
+	t.Run("metadata", func(t *testing.T) {
+		table := timetable.New(List{elV(day0, 1)})
+		require.NoError(t, table.SetColumnInfo(0, timetable.ColumnInfo{Metadata: map[string]string{"currency": "USD"}}))
+		buf, err := json.Marshal(table)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"columns":[{"metadata":{"currency":"USD"}}],"times":["2022-10-20T00:00:00Z"],"values":[[1]]}`, string(buf))
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		buf, err := json.Marshal(timetable.New[Value]())
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"times":[],"values":[]}`, string(buf))
+
+		var decoded Table
+		require.NoError(t, json.Unmarshal(buf, &decoded))
+		updated := decoded.AddColumnFillMissingWithZero(List{elV(day0, 1)})
+		assert.Equal(t, []time.Time{date(day0)}, updated.Times(), "it behaves like a new table")
+	})
+
+	for _, tt := range []struct {
+		Name string
+		JSON string
+	}{
+		{Name: "unsorted times", JSON: `{"times":["2022-10-21T00:00:00Z","2022-10-20T00:00:00Z"],"values":[[1,2]]}`},
+		{Name: "repeated times", JSON: `{"times":["2022-10-20T00:00:00Z","2022-10-20T00:00:00Z"],"values":[[1,2]]}`},
+		{Name: "short column", JSON: `{"times":["2022-10-20T00:00:00Z","2022-10-21T00:00:00Z"],"values":[[1,2],[1]]}`},
+		{Name: "short row", JSON: `{"rows":[{"time":"2022-10-20T00:00:00Z","values":[1,2]},{"time":"2022-10-21T00:00:00Z","values":[1]}]}`},
+		{Name: "long row", JSON: `{"rows":[{"time":"2022-10-20T00:00:00Z","values":[1,2]},{"time":"2022-10-21T00:00:00Z","values":[3,4,5]}]}`},
+		{Name: "column count", JSON: `{"columns":[{"name":"AAA"}],"times":[],"values":[[],[]]}`},
+		{Name: "duplicate names", JSON: `{"columns":[{"name":"AAA"},{"name":"AAA"}],"times":[],"values":[[],[]]}`},
+		{Name: "both layouts", JSON: `{"times":[],"rows":[]}`},
+	} {
+		t.Run(tt.Name, func(t *testing.T) {
+			var decoded Table
+			assert.Error(t, json.Unmarshal([]byte(tt.JSON), &decoded))
+		})
+	}
+
+	t.Run("row error names the row", func(t *testing.T) {
+		var decoded Table
+		err := json.Unmarshal([]byte(`{"rows":[{"time":"2022-10-20T00:00:00Z","values":[1,2]},{"time":"2022-10-21T00:00:00Z","values":[3,4,5]}]}`), &decoded)
+		assert.ErrorContains(t, err, "row 1")
+	})
+}
@@ -16,27 +16,32 @@ func (list List[Value]) Between(t0, t1 time.Time) List[Value] {
 	if len(list) == 0 {
 		return nil
 	}
+	slices.SortFunc(list, Cell[Value].compareTimes)
+	firstIndex, lastIndex := betweenIndexes(list, t0, t1, Cell[Value].compareTime)
+	return list[firstIndex:lastIndex:lastIndex]
+}
+
+// betweenIndexes returns the bounds of the elements of a non-empty sorted slice from t0 to t1 inclusive.
+func betweenIndexes[E any](list []E, t0, t1 time.Time, cmp func(E, time.Time) int) (int, int) {
 	if t1.Before(t0) {
 		t0, t1 = t1, t0
 	}
-	slices.SortFunc(list, Cell[Value].compareTimes)
-	last := list[len(list)-1].time
+	last := list[len(list)-1]
 
 	var firstIndex, lastIndex int
-	if t0.After(last) {
+	if cmp(last, t0) < 0 {
 		firstIndex = len(list)
 	} else {
-		firstIndex, _ = slices.BinarySearchFunc(list, Cell[Value]{time: t0}, Cell[Value].compareTimes)
+		firstIndex, _ = slices.BinarySearchFunc(list, t0, cmp)
 	}
 
-	switch i, ok := slices.BinarySearchFunc(list, Cell[Value]{time: t1}, Cell[Value].compareTimes); {
-	case t1.After(last):
+	switch i, ok := slices.BinarySearchFunc(list, t1, cmp); {
+	case cmp(last, t1) < 0:
 		lastIndex = len(list)
 	case ok:
 		lastIndex = i + 1
 	default:
 		lastIndex = i
 	}
-
-	return list[firstIndex:lastIndex:lastIndex]
+	return firstIndex, lastIndex
 }
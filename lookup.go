@@ -0,0 +1,103 @@
+package timetable
+
+import (
+	"slices"
+	"time"
+)
+
+// RowAsOf returns the latest row at or before t along with the time of that row.
+func (table *Compact[Value]) RowAsOf(t time.Time) (time.Time, []Value, bool) {
+	return table.rowAt(asOfIndex(table.times, t, time.Time.Compare))
+}
+
+// RowAtOrAfter returns the earliest row at or after t along with the time of that row.
+func (table *Compact[Value]) RowAtOrAfter(t time.Time) (time.Time, []Value, bool) {
+	return table.rowAt(atOrAfterIndex(table.times, t, time.Time.Compare))
+}
+
+// RowNearest returns the row closest to t along with the time of that row.
+// Rows further than tolerance from t are not considered.
+// When two rows are equally close, the earlier one is returned.
+func (table *Compact[Value]) RowNearest(t time.Time, tolerance time.Duration) (time.Time, []Value, bool) {
+	return table.rowAt(nearestIndex(table.times, t, tolerance, time.Time.Compare, func(t time.Time) time.Time { return t }))
+}
+
+func (table *Compact[Value]) rowAt(index int) (time.Time, []Value, bool) {
+	if table.isEmpty() || index < 0 {
+		var zero [0]Value
+		return time.Time{}, zero[:], false
+	}
+	return table.times[index], table.row(index), true
+}
+
+func (table *Compact[Value]) row(index int) []Value {
+	list := make([]Value, len(table.values))
+	for column := range table.values {
+		list[column] = table.values[column][index]
+	}
+	return list
+}
+
+// AsOf returns the latest cell at or before t. Of several cells at the same time, the last one in the list is the latest.
+func (list List[Value]) AsOf(t time.Time) (Cell[Value], bool) {
+	slices.SortStableFunc(list, Cell[Value].compareTimes)
+	return list.cellAt(asOfIndex(list, Cell[Value]{time: t}, Cell[Value].compareTimes))
+}
+
+// AtOrAfter returns the earliest cell at or after t.
+func (list List[Value]) AtOrAfter(t time.Time) (Cell[Value], bool) {
+	slices.SortStableFunc(list, Cell[Value].compareTimes)
+	return list.cellAt(atOrAfterIndex(list, Cell[Value]{time: t}, Cell[Value].compareTimes))
+}
+
+// Nearest returns the cell closest to t. Cells further than tolerance from t are not considered.
+// When two cells are equally close, the earlier one is returned.
+func (list List[Value]) Nearest(t time.Time, tolerance time.Duration) (Cell[Value], bool) {
+	slices.SortStableFunc(list, Cell[Value].compareTimes)
+	return list.cellAt(nearestIndex(list, Cell[Value]{time: t}, tolerance, Cell[Value].compareTimes, Cell[Value].Time))
+}
+
+func (list List[Value]) cellAt(index int) (Cell[Value], bool) {
+	if index < 0 {
+		return Cell[Value]{}, false
+	}
+	return list[index], true
+}
+
+// asOfIndex returns the index of the last element at or before target, or -1 when there is none.
+func asOfIndex[E any](list []E, target E, cmp func(E, E) int) int {
+	// Treating equal elements as before the target finds the first element after it.
+	after, _ := slices.BinarySearchFunc(list, target, func(e, target E) int {
+		if cmp(e, target) <= 0 {
+			return -1
+		}
+		return 1
+	})
+	return after - 1
+}
+
+func atOrAfterIndex[E any](list []E, target E, cmp func(E, E) int) int {
+	index, _ := slices.BinarySearchFunc(list, target, cmp)
+	if index == len(list) {
+		return -1
+	}
+	return index
+}
+
+func nearestIndex[E any](list []E, target E, tolerance time.Duration, cmp func(E, E) int, timeOf func(E) time.Time) int {
+	before := asOfIndex(list, target, cmp)
+	after := atOrAfterIndex(list, target, cmp)
+	t := timeOf(target)
+	index, distance := -1, tolerance
+	if before >= 0 {
+		if d := t.Sub(timeOf(list[before])); d <= distance {
+			index, distance = before, d
+		}
+	}
+	if after >= 0 {
+		if d := timeOf(list[after]).Sub(t); d < distance || (index < 0 && d <= distance) {
+			index = after
+		}
+	}
+	return index
+}
@@ -0,0 +1,109 @@
+package timetable_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/portfoliotree/timetable"
+)
+
+func TestCompact_RowAsOf(t *testing.T) {
+	table := timetable.New(
+		List{elV(day0, 1), elV(day1, 2), elV(day3, 4)},
+		List{elV(day0, 10), elV(day1, 20), elV(day3, 40)},
+	)
+
+	for _, tt := range []struct {
+		Name     string
+		Lookup   func(time.Time) (time.Time, []Value, bool)
+		At       string
+		Found    bool
+		RowTime  string
+		RowValue []Value
+	}{
+		{Name: "as of exact", Lookup: table.RowAsOf, At: day1, Found: true, RowTime: day1, RowValue: []Value{2, 20}},
+		{Name: "as of between", Lookup: table.RowAsOf, At: day2, Found: true, RowTime: day1, RowValue: []Value{2, 20}},
+		{Name: "as of after", Lookup: table.RowAsOf, At: dayAfter, Found: true, RowTime: day3, RowValue: []Value{4, 40}},
+		{Name: "as of before", Lookup: table.RowAsOf, At: dayBefore},
+		{Name: "at or after exact", Lookup: table.RowAtOrAfter, At: day1, Found: true, RowTime: day1, RowValue: []Value{2, 20}},
+		{Name: "at or after between", Lookup: table.RowAtOrAfter, At: day2, Found: true, RowTime: day3, RowValue: []Value{4, 40}},
+		{Name: "at or after before", Lookup: table.RowAtOrAfter, At: dayBefore, Found: true, RowTime: day0, RowValue: []Value{1, 10}},
+		{Name: "at or after after", Lookup: table.RowAtOrAfter, At: dayAfter},
+	} {
+		t.Run(tt.Name, func(t *testing.T) {
+			rowTime, row, ok := tt.Lookup(date(tt.At))
+			assert.Equal(t, tt.Found, ok)
+			if !tt.Found {
+				assert.Len(t, row, 0)
+				return
+			}
+			assert.Equal(t, date(tt.RowTime), rowTime)
+			assert.Equal(t, tt.RowValue, row)
+		})
+	}
+
+	t.Run("empty table", func(t *testing.T) {
+		_, row, ok := timetable.New[Value]().RowAsOf(date(day0))
+		assert.False(t, ok)
+		assert.Len(t, row, 0)
+	})
+}
+
+func TestCompact_RowNearest(t *testing.T) {
+	table := timetable.New(List{elV(day0, 1), elV(day1, 2), elV(day3, 4)})
+	const day = 24 * time.Hour
+
+	rowTime, row, ok := table.RowNearest(date(day2), 2*day)
+	assert.True(t, ok)
+	assert.Equal(t, date(day3), rowTime, "Tuesday is closer to Monday than Friday")
+	assert.Equal(t, []Value{4}, row)
+
+	rowTime, _, ok = table.RowNearest(date(day0).Add(12*time.Hour), day)
+	assert.True(t, ok)
+	assert.Equal(t, date(day0), rowTime, "ties go to the earlier row")
+
+	_, _, ok = table.RowNearest(date(day3).AddDate(0, 0, 3), 2*day)
+	assert.False(t, ok)
+
+	_, _, ok = table.RowNearest(date(dayBefore), day)
+	assert.True(t, ok)
+}
+
+func TestList_AsOf(t *testing.T) {
+	list := List{elV(day3, 4), elV(day0, 1), elV(day1, 2)}
+
+	if cell, ok := list.AsOf(date(day2)); assert.True(t, ok) {
+		assert.Equal(t, elV(day1, 2), cell)
+	}
+	_, ok := list.AsOf(date(dayBefore))
+	assert.False(t, ok)
+
+	t.Run("repeated times", func(t *testing.T) {
+		list := List{elV(day0, 0), elV(day1, 1), elV(day1, 2), elV(day3, 3)}
+		for _, at := range []string{day1, day2} {
+			if cell, ok := list.AsOf(date(at)); assert.True(t, ok) {
+				assert.Equal(t, elV(day1, 2), cell, at)
+			}
+		}
+		if cell, ok := list.AtOrAfter(date(day1)); assert.True(t, ok) {
+			assert.Equal(t, elV(day1, 1), cell)
+		}
+	})
+
+	if cell, ok := list.AtOrAfter(date(day2)); assert.True(t, ok) {
+		assert.Equal(t, elV(day3, 4), cell)
+	}
+	_, ok = list.AtOrAfter(date(dayAfter))
+	assert.False(t, ok)
+
+	if cell, ok := list.Nearest(date(day2), 48*time.Hour); assert.True(t, ok) {
+		assert.Equal(t, elV(day3, 4), cell)
+	}
+	_, ok = list.Nearest(date(day2), time.Hour)
+	assert.False(t, ok)
+
+	_, ok = List(nil).AsOf(date(day0))
+	assert.False(t, ok)
+}
@@ -0,0 +1,158 @@
+package timetable
+
+import (
+	"fmt"
+	"slices"
+	"time"
+)
+
+// Map returns a table with the same times and columns as table where each value is the result of fn.
+// fn is called with the time and column of each cell. Missing cells stay missing.
+func Map[A, B any](table *Compact[A], fn func(time.Time, int, A) B) *Compact[B] {
+	values := make([][]B, len(table.values))
+	for column, input := range table.values {
+		result := make([]B, len(input))
+		for row, value := range input {
+			result[row] = fn(table.times[row], column, value)
+		}
+		values[column] = result
+	}
+	return &Compact[B]{times: slices.Clip(table.times), values: values, columns: slices.Clone(table.columns), missing: cloneMask(table.missing, 0, len(table.times))}
+}
+
+// MapColumns returns a table with the same times and columns as table where each column is the result of fn.
+// fn must not modify values and must return one value per row. MapColumns returns an error if it does not.
+// Missing cells stay missing.
+func MapColumns[A, B any](table *Compact[A], fn func(column int, values []A) []B) (*Compact[B], error) {
+	values := make([][]B, len(table.values))
+	for column, input := range table.values {
+		result := fn(column, slices.Clip(input))
+		if len(result) != len(input) {
+			return nil, fmt.Errorf("column %d: got %d values for %d rows", column, len(result), len(input))
+		}
+		values[column] = result
+	}
+	return &Compact[B]{times: slices.Clip(table.times), values: values, columns: slices.Clone(table.columns), missing: cloneMask(table.missing, 0, len(table.times))}, nil
+}
+
+// MapRows returns a table with the same times as table where each row is the result of fn.
+// fn may return a different number of values than it is given, but it must return the same number for every row.
+// MapRows returns an error if it does not. Column names and missing cells are kept when the number of columns does not change.
+func MapRows[A, B any](table *Compact[A], fn func(t time.Time, values []A) []B) (*Compact[B], error) {
+	var values [][]B
+	for row, t := range table.times {
+		result := fn(t, table.row(row))
+		if row == 0 {
+			values = make([][]B, len(result))
+			for column := range values {
+				values[column] = make([]B, len(table.times))
+			}
+		}
+		if len(result) != len(values) {
+			return nil, fmt.Errorf("row at %s: got %d values, the first row has %d", t.Format(time.RFC3339), len(result), len(values))
+		}
+		for column, value := range result {
+			values[column][row] = value
+		}
+	}
+	if len(table.times) == 0 {
+		values = make([][]B, len(table.values))
+	}
+	var (
+		columns []ColumnInfo
+		missing [][]bool
+	)
+	if len(values) == len(table.values) {
+		columns, missing = slices.Clone(table.columns), cloneMask(table.missing, 0, len(table.times))
+	}
+	return &Compact[B]{times: slices.Clip(table.times), values: values, columns: columns, missing: missing}, nil
+}
+
+// Zip aligns two tables on time with join and combines their cells with fn, column by column.
+// The missing function is called for each cell at a time that only one of the tables has.
+// When missing is nil those cells are marked missing instead. Cells missing in either table are missing in the result.
+// Zip returns an error if the tables have a different number of columns.
+// The result has the column names and metadata of a.
+func Zip[A, B, C any](a *Compact[A], b *Compact[B], fn func(t time.Time, column int, a A, b B) C, missing func(time.Time, int) C, join JoinMode) (*Compact[C], error) {
+	if a == nil {
+		a = new(Compact[A])
+	}
+	if b == nil {
+		b = new(Compact[B])
+	}
+	if a.NumberOfColumns() != b.NumberOfColumns() {
+		return nil, fmt.Errorf("can not zip a table with %d columns and a table with %d columns", a.NumberOfColumns(), b.NumberOfColumns())
+	}
+	maskGaps := missing == nil
+	if maskGaps {
+		missing = zeroValue[C]
+	}
+	times := joinTimes(a.times, []List[struct{}]{timesList(b.times)}, join)
+	aRows := mapRows(times, a.times, time.Time.Compare)
+	bRows := mapRows(times, b.times, time.Time.Compare)
+
+	values := make([][]C, a.NumberOfColumns())
+	masks := make([][]bool, a.NumberOfColumns())
+	for column := range values {
+		result := make([]C, len(times))
+		mask := make([]bool, len(times))
+		for row, t := range times {
+			if aRows[row] < 0 || bRows[row] < 0 {
+				result[row] = missing(t, column)
+				mask[row] = maskGaps
+				continue
+			}
+			if a.IsMissing(aRows[row], column) || b.IsMissing(bRows[row], column) {
+				mask[row] = true
+				continue
+			}
+			result[row] = fn(t, column, a.values[column][aRows[row]], b.values[column][bRows[row]])
+		}
+		values[column], masks[column] = result, mask
+	}
+	return &Compact[C]{times: times, values: values, columns: slices.Clone(a.columns), missing: compactMask(masks)}, nil
+}
+
+func timesList(times []time.Time) List[struct{}] {
+	list := make(List[struct{}], len(times))
+	for i, t := range times {
+		list[i].time = t
+	}
+	return list
+}
+
+// ListMap is like Map. A list has a single column, so it also covers MapRows.
+func ListMap[A, B any](list List[A], fn func(time.Time, A) B) List[B] {
+	result := make(List[B], len(list))
+	for i, cell := range list {
+		result[i] = Cell[B]{time: cell.time, value: fn(cell.time, cell.value), missing: cell.missing}
+	}
+	return result
+}
+
+// ListMapColumn is like MapColumns. The values are passed to fn in the order of the list.
+func ListMapColumn[A, B any](list List[A], fn func(values []A) []B) (List[B], error) {
+	values := make([]A, len(list))
+	for i, cell := range list {
+		values[i] = cell.value
+	}
+	mapped := fn(values)
+	if len(mapped) != len(list) {
+		return nil, fmt.Errorf("got %d values for %d cells", len(mapped), len(list))
+	}
+	result := make(List[B], len(list))
+	for i, cell := range list {
+		result[i] = Cell[B]{time: cell.time, value: mapped[i], missing: cell.missing}
+	}
+	return result, nil
+}
+
+// ListZip is like Zip. When a list has more than one cell at a time, the first one is used.
+func ListZip[A, B, C any](a List[A], b List[B], fn func(t time.Time, a A, b B) C, missing func(time.Time) C, join JoinMode) List[C] {
+	var missingCell func(time.Time, int) C
+	if missing != nil {
+		missingCell = func(t time.Time, _ int) C { return missing(t) }
+	}
+	table, _ := Zip(New(a), New(b), func(t time.Time, _ int, a A, b B) C { return fn(t, a, b) }, missingCell, join)
+	return listColumn(table)
+}
@@ -0,0 +1,156 @@
+package timetable_test
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/portfoliotree/timetable"
+)
+
+func TestMap(t *testing.T) {
+	table, err := timetable.NewNamed([]string{"a", "b"},
+		List{elV(day0, 1), elV(day1, 2)},
+		List{elV(day0, 10), elV(day1, 20)},
+	)
+	require.NoError(t, err)
+
+	result := timetable.Map(table, func(_ time.Time, column int, value Value) string {
+		return strconv.Itoa(column) + ":" + strconv.Itoa(value)
+	})
+	assert.Equal(t, [][]string{{"0:1", "0:2"}, {"1:10", "1:20"}}, result.Values())
+	assert.Equal(t, table.Times(), result.Times())
+	assert.Equal(t, []string{"a", "b"}, result.ColumnNames())
+}
+
+func TestMapColumns(t *testing.T) {
+	table := timetable.New(List{elV(day0, 1), elV(day1, 2)}, List{elV(day0, 10), elV(day1, 20)})
+
+	t.Run("one value per row", func(t *testing.T) {
+		result, err := timetable.MapColumns(table, func(_ int, values []Value) []float64 {
+			total := 0
+			for _, v := range values {
+				total += v
+			}
+			shares := make([]float64, len(values))
+			for i, v := range values {
+				shares[i] = float64(v) / float64(total)
+			}
+			return shares
+		})
+		require.NoError(t, err)
+		assert.InDeltaSlice(t, []float64{1.0 / 3, 2.0 / 3}, result.Values()[1], 1e-12)
+	})
+
+	t.Run("wrong number of values", func(t *testing.T) {
+		_, err := timetable.MapColumns(table, func(_ int, values []Value) []Value { return values[1:] })
+		assert.Error(t, err)
+	})
+}
+
+func TestMapRows(t *testing.T) {
+	table, err := timetable.NewNamed([]string{"price", "shares"},
+		List{elV(day0, 3), elV(day1, 4)},
+		List{elV(day0, 10), elV(day1, 20)},
+	)
+	require.NoError(t, err)
+
+	t.Run("fewer columns", func(t *testing.T) {
+		result, err := timetable.MapRows(table, func(_ time.Time, values []Value) []Value {
+			return []Value{values[0] * values[1]}
+		})
+		require.NoError(t, err)
+		assert.Equal(t, [][]Value{{30, 80}}, result.Values())
+		assert.Equal(t, []string{""}, result.ColumnNames())
+	})
+
+	t.Run("same columns keep names", func(t *testing.T) {
+		result, err := timetable.MapRows(table, func(_ time.Time, values []Value) []Value {
+			return []Value{values[1], values[0]}
+		})
+		require.NoError(t, err)
+		assert.Equal(t, [][]Value{{10, 20}, {3, 4}}, result.Values())
+		assert.Equal(t, []string{"price", "shares"}, result.ColumnNames())
+	})
+
+	t.Run("inconsistent rows", func(t *testing.T) {
+		_, err := timetable.MapRows(table, func(t time.Time, values []Value) []Value {
+			if t.Equal(date(day1)) {
+				return values[:1]
+			}
+			return values
+		})
+		assert.Error(t, err)
+	})
+
+	t.Run("no rows", func(t *testing.T) {
+		result, err := timetable.MapRows(timetable.New(List{}), func(time.Time, []Value) []Value { return nil })
+		require.NoError(t, err)
+		assert.Equal(t, 1, result.NumberOfColumns())
+		assert.Equal(t, 0, result.NumberOfRows())
+	})
+}
+
+func TestZip(t *testing.T) {
+	prices := timetable.New(List{elV(day0, 3), elV(day1, 4), elV(day3, 5)})
+	shares := timetable.New(List{elV(day1, 20), elV(day2, 30)})
+	marketValue := func(_ time.Time, _ int, price, shares Value) Value { return price * shares }
+
+	for _, tt := range []struct {
+		Join   timetable.JoinMode
+		Times  []time.Time
+		Values [][]Value
+	}{
+		{Join: timetable.JoinOverlap, Times: []time.Time{date(day1), date(day2)}, Values: [][]Value{{80, -1}}},
+		{Join: timetable.JoinInner, Times: []time.Time{date(day1)}, Values: [][]Value{{80}}},
+		{Join: timetable.JoinOuter, Times: []time.Time{date(day0), date(day1), date(day2), date(day3)}, Values: [][]Value{{-1, 80, -1, -1}}},
+		{Join: timetable.JoinLeft, Times: []time.Time{date(day0), date(day1), date(day3)}, Values: [][]Value{{-1, 80, -1}}},
+		{Join: timetable.JoinRight, Times: []time.Time{date(day1), date(day2)}, Values: [][]Value{{80, -1}}},
+	} {
+		t.Run(tt.Join.String(), func(t *testing.T) {
+			result, err := timetable.Zip(prices, shares, marketValue, missingNegativeOne, tt.Join)
+			require.NoError(t, err)
+			assert.Equal(t, tt.Times, result.Times())
+			assert.Equal(t, tt.Values, result.Values())
+		})
+	}
+
+	t.Run("different number of columns", func(t *testing.T) {
+		_, err := timetable.Zip(prices, timetable.New(List{}, List{}), marketValue, nil, timetable.JoinInner)
+		assert.Error(t, err)
+	})
+
+	t.Run("nil tables", func(t *testing.T) {
+		result, err := timetable.Zip[Value, Value, Value](nil, nil, marketValue, nil, timetable.JoinOuter)
+		require.NoError(t, err)
+		assert.Equal(t, 0, result.NumberOfColumns())
+	})
+}
+
+func TestListMap(t *testing.T) {
+	list := List{elV(day0, 1), elV(day1, 2)}
+	result := timetable.ListMap(list, func(_ time.Time, value Value) float64 { return float64(value) / 2 })
+	assert.Equal(t, timetable.List[float64]{timetable.NewCell(date(day0), 0.5), timetable.NewCell(date(day1), 1.0)}, result)
+}
+
+func TestListMapColumn(t *testing.T) {
+	list := List{elV(day0, 1), elV(day1, 2)}
+	result, err := timetable.ListMapColumn(list, func(values []Value) []Value {
+		return []Value{values[1], values[0]}
+	})
+	require.NoError(t, err)
+	assert.Equal(t, List{elV(day0, 2), elV(day1, 1)}, result)
+
+	_, err = timetable.ListMapColumn(list, func([]Value) []Value { return nil })
+	assert.Error(t, err)
+}
+
+func TestListZip(t *testing.T) {
+	prices := List{elV(day0, 3), elV(day1, 4)}
+	shares := List{elV(day1, 20), elV(day0, 10)}
+	result := timetable.ListZip(prices, shares, func(_ time.Time, price, shares Value) Value { return price * shares }, nil, timetable.JoinInner)
+	assert.Equal(t, List{elV(day0, 30), elV(day1, 80)}, result)
+}
@@ -0,0 +1,113 @@
+package timetable
+
+import (
+	"slices"
+	"time"
+)
+
+// IsMissing reports whether the cell at row and column has no value.
+// Missing cells hold the zero value unless Fill left them unfilled.
+func (table *Compact[Value]) IsMissing(row, column int) bool {
+	mask := table.missingColumn(column)
+	return row >= 0 && row < len(mask) && mask[row]
+}
+
+// HasMissing reports whether any cell of the table is missing.
+func (table *Compact[Value]) HasMissing() bool {
+	return table != nil && slices.ContainsFunc(table.missing, func(missing []bool) bool { return slices.Contains(missing, true) })
+}
+
+// RowMissing reports which cells of the row at t are missing. It is the mask for the values returned by Row.
+func (table *Compact[Value]) RowMissing(t time.Time) ([]bool, bool) {
+	index, found := slices.BinarySearchFunc(table.times, t, time.Time.Compare)
+	if !found {
+		return nil, false
+	}
+	return table.rowMissing(index), true
+}
+
+// FillMissing returns a copy of the table where missing cells are replaced using fill.
+// Cells fill leaves unfilled stay missing. Use ConstantFill to replace every missing cell.
+func (table *Compact[Value]) FillMissing(fill Filler[Value]) *Compact[Value] {
+	return table.Fill(nil, fill)
+}
+
+func (table *Compact[Value]) rowMissing(index int) []bool {
+	mask := make([]bool, len(table.values))
+	for column := range mask {
+		mask[column] = table.IsMissing(index, column)
+	}
+	return mask
+}
+
+// missingColumn returns the mask of a column. It is nil when no cell in the column is missing.
+func (table *Compact[Value]) missingColumn(column int) []bool {
+	if table == nil || column < 0 || column >= len(table.missing) {
+		return nil
+	}
+	return table.missing[column]
+}
+
+func (table *Compact[Value]) cell(row, column int) Cell[Value] {
+	return Cell[Value]{time: table.times[row], value: table.values[column][row], missing: table.IsMissing(row, column)}
+}
+
+// compactMask returns nil when no column of mask has a missing cell,
+// so tables without missing cells never carry a mask.
+func compactMask(mask [][]bool) [][]bool {
+	for column, missing := range mask {
+		if !slices.Contains(missing, true) {
+			mask[column] = nil
+		}
+	}
+	if !slices.ContainsFunc(mask, func(missing []bool) bool { return missing != nil }) {
+		return nil
+	}
+	return mask
+}
+
+// cloneMask copies the rows from start to end of each column of mask.
+func cloneMask(mask [][]bool, start, end int) [][]bool {
+	if mask == nil {
+		return nil
+	}
+	result := make([][]bool, len(mask))
+	for column, missing := range mask {
+		if missing != nil {
+			result[column] = slices.Clone(missing[start:end])
+		}
+	}
+	return compactMask(result)
+}
+
+// selectMask copies the given rows of each column of mask.
+func selectMask(mask [][]bool, rows []int) [][]bool {
+	if mask == nil {
+		return nil
+	}
+	result := make([][]bool, len(mask))
+	for column, missing := range mask {
+		if missing == nil {
+			continue
+		}
+		result[column] = make([]bool, len(rows))
+		for i, row := range rows {
+			result[column][i] = missing[row]
+		}
+	}
+	return compactMask(result)
+}
+
+// setMissing marks or clears a cell in the mask, allocating the mask the first time a cell is marked.
+func (table *Compact[Value]) setMissing(row, column int, missing bool) {
+	if !missing && table.missingColumn(column) == nil {
+		return
+	}
+	if table.missing == nil {
+		table.missing = make([][]bool, len(table.values))
+	}
+	if table.missing[column] == nil {
+		table.missing[column] = make([]bool, len(table.times))
+	}
+	table.missing[column][row] = missing
+}
@@ -0,0 +1,178 @@
+package timetable_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/portfoliotree/timetable"
+)
+
+// missingCells returns the mask of a table as a row of missing flags per column.
+func missingCells[V any](table *timetable.Compact[V]) [][]bool {
+	mask := make([][]bool, table.NumberOfColumns())
+	for column := range mask {
+		mask[column] = make([]bool, table.NumberOfRows())
+		for row := range mask[column] {
+			mask[column][row] = table.IsMissing(row, column)
+		}
+	}
+	return mask
+}
+
+func maskedTable() *Table {
+	return timetable.New(List{elV(day0, 1), elV(day1, 2), elV(day2, 3), elV(day3, 4)}).
+		AddColumnWithJoin(List{elV(day0, 10), timetable.NewMissingCell[Value](date(day1)), elV(day3, 40)}, nil, timetable.JoinLeft)
+}
+
+func TestCompact_IsMissing(t *testing.T) {
+	t.Run("a nil missing function marks gaps", func(t *testing.T) {
+		table := maskedTable()
+		assert.Equal(t, [][]Value{{1, 2, 3, 4}, {10, 0, 0, 40}}, table.Values())
+		assert.Equal(t, [][]bool{{false, false, false, false}, {false, true, true, false}}, missingCells(table))
+		assert.True(t, table.HasMissing())
+	})
+
+	t.Run("a missing function fills gaps", func(t *testing.T) {
+		table := timetable.New(List{elV(day0, 1), elV(day1, 2)}).AddColumnFillMissingWithZero(List{elV(day0, 10)})
+		assert.False(t, table.HasMissing())
+		assert.False(t, table.IsMissing(1, 1))
+	})
+
+	t.Run("out of range", func(t *testing.T) {
+		table := maskedTable()
+		assert.False(t, table.IsMissing(-1, 1))
+		assert.False(t, table.IsMissing(1, 2))
+		var nilTable *Table
+		assert.False(t, nilTable.IsMissing(0, 0))
+	})
+
+	t.Run("between", func(t *testing.T) {
+		table := maskedTable().Between(date(day2), date(day3))
+		assert.Equal(t, [][]bool{{false, false}, {true, false}}, missingCells(table))
+		assert.False(t, maskedTable().Between(date(day3), date(day3)).HasMissing())
+	})
+
+	t.Run("column", func(t *testing.T) {
+		list, ok := maskedTable().Column(1)
+		require.True(t, ok)
+		assert.Equal(t, List{elV(day0, 10), timetable.NewMissingCell[Value](date(day1)), timetable.NewMissingCell[Value](date(day2)), elV(day3, 40)}, list)
+	})
+
+	t.Run("row", func(t *testing.T) {
+		table := maskedTable()
+		missing, ok := table.RowMissing(date(day1))
+		require.True(t, ok)
+		assert.Equal(t, []bool{false, true}, missing)
+		_, ok = table.RowMissing(date(dayAfter))
+		assert.False(t, ok)
+
+		for _, row := range table.RowsBetween(date(day1), date(day1)) {
+			assert.True(t, row.IsMissing(1))
+		}
+	})
+
+	t.Run("later joins keep missing cells", func(t *testing.T) {
+		table := maskedTable().AddColumnWithJoin(List{elV(day1, 200)}, missingNegativeOne, timetable.JoinInner)
+		assert.Equal(t, [][]bool{{false}, {true}, {false}}, missingCells(table))
+	})
+}
+
+func TestCompact_FillMissing(t *testing.T) {
+	t.Run("forward fill", func(t *testing.T) {
+		table := maskedTable().FillMissing(timetable.ForwardFill[Value](0))
+		assert.Equal(t, [][]Value{{1, 2, 3, 4}, {10, 10, 10, 40}}, table.Values())
+		assert.False(t, table.HasMissing())
+	})
+
+	t.Run("unfilled cells stay missing", func(t *testing.T) {
+		table := maskedTable().FillMissing(timetable.ForwardFill[Value](1))
+		assert.Equal(t, missingCells(maskedTable()), missingCells(table))
+	})
+
+	t.Run("constant", func(t *testing.T) {
+		original := maskedTable()
+		table := original.FillMissing(timetable.ConstantFill[Value](-1))
+		assert.Equal(t, [][]Value{{1, 2, 3, 4}, {10, -1, -1, 40}}, table.Values())
+		assert.False(t, table.HasMissing())
+		assert.True(t, original.HasMissing(), "it does not modify the table")
+	})
+}
+
+func TestCompact_Resample_missing(t *testing.T) {
+	table := maskedTable().Resample(timetable.Week, nil, timetable.Sum[Value])
+	assert.Equal(t, [][]Value{{3, 7}, {10, 40}}, table.Values(), "missing cells are left out of each group")
+
+	allMissing := timetable.New(List{elV(day0, 1), elV(day1, 2)}).
+		AddColumnWithJoin(List{elV(day2, 3)}, nil, timetable.JoinLeft).
+		Resample(timetable.Week, nil, timetable.Sum[Value])
+	assert.Equal(t, [][]bool{{false}, {true}}, missingCells(allMissing))
+
+	list := List{elV(day0, 1), timetable.NewMissingCell[Value](date(day3))}.Resample(timetable.Week, nil, timetable.Sum[Value])
+	assert.Equal(t, List{elV(day0, 1), timetable.NewMissingCell[Value](date(day3))}, list)
+}
+
+func TestCompact_Rolling_missing(t *testing.T) {
+	table := maskedTable().Rolling(timetable.Window{Rows: 2, MinObservations: 1}, timetable.RollingSum[Value])
+	assert.Equal(t, [][]Value{{1, 3, 5, 7}, {10, 10, 0, 40}}, table.Values())
+	assert.Equal(t, [][]bool{{false, false, false, false}, {false, false, true, false}}, missingCells(table))
+
+	full := maskedTable().Rolling(timetable.RowWindow(2), timetable.RollingSum[Value])
+	assert.Equal(t, [][]bool{{true, false, false, false}, {true, true, true, true}}, missingCells(full))
+}
+
+func TestPctChange_missing(t *testing.T) {
+	prices := timetable.New(timetable.List[float64]{
+		timetable.NewCell(date(day0), 100.0),
+		timetable.NewMissingCell[float64](date(day1)),
+		timetable.NewCell(date(day2), 110.0),
+		timetable.NewCell(date(day3), 121.0),
+	})
+	returns := timetable.PctChange(prices, 1, timetable.MarkFirstRowsMissing)
+	assert.Equal(t, [][]bool{{true, true, true, false}}, missingCells(returns))
+	assert.True(t, math.IsNaN(returns.Values()[0][2]))
+	assert.InDelta(t, 0.1, returns.Values()[0][3], 1e-12)
+
+	growth := timetable.CumulativeReturn(returns)
+	assert.InDeltaSlice(t, []float64{1, 1, 1, 1.1}, growth.Values()[0], 1e-12)
+	assert.False(t, growth.HasMissing())
+}
+
+func TestZip_missing(t *testing.T) {
+	a := maskedTable()
+	b := timetable.New(List{elV(day0, 1), elV(day1, 1)}, List{elV(day0, 1), elV(day1, 1)})
+	result, err := timetable.Zip(a, b, func(_ time.Time, _ int, a, b Value) Value { return a + b }, nil, timetable.JoinLeft)
+	require.NoError(t, err)
+	assert.Equal(t, [][]bool{{false, false, true, true}, {false, true, true, true}}, missingCells(result))
+	assert.Equal(t, missingCells(maskedTable()), missingCells(timetable.Map(a, func(_ time.Time, _ int, v Value) Value { return v })))
+}
+
+func TestCompact_SetMissing(t *testing.T) {
+	table := maskedTable()
+	require.NoError(t, table.Set(date(day1), 1, 20))
+	assert.False(t, table.IsMissing(1, 1), "set clears the missing mark")
+
+	view := table.Between(date(day0), date(day1))
+	require.NoError(t, table.SetMissing(date(day0), 0))
+	assert.True(t, table.IsMissing(0, 0))
+	assert.Equal(t, 1, table.Values()[0][0], "the stored value is kept")
+	assert.Equal(t, 1, view.Values()[0][0], "views do not see a zero without a mark")
+	assert.False(t, view.IsMissing(0, 0))
+	require.NoError(t, table.SetMissing(date(day0), 1))
+	assert.True(t, view.IsMissing(0, 1), "views share the mask of a column that already had one")
+	require.NoError(t, table.Set(date(day0), 1, 10))
+	assert.Error(t, table.SetMissing(date(dayAfter), 0))
+
+	require.NoError(t, table.InsertRow(date(dayBefore), 0, 0))
+	require.NoError(t, table.AppendRow(date(dayAfter), 5, 50))
+	assert.Equal(t, [][]bool{{false, true, false, false, false, false}, {false, false, false, true, false, false}}, missingCells(table))
+
+	require.NoError(t, table.ReorderColumns([]int{1, 0}))
+	assert.True(t, table.IsMissing(1, 1))
+	assert.Equal(t, 2, table.DeleteRowsBetween(date(dayBefore), date(day0)))
+	require.NoError(t, table.DeleteColumn(1))
+	assert.Equal(t, [][]bool{{false, true, false, false}}, missingCells(table))
+}
@@ -0,0 +1,203 @@
+package timetable
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+)
+
+var errNilTable = errors.New("table is nil")
+
+// Set replaces the value at time t in column and clears its missing mark. It writes to the table in place,
+// so the change is visible through tables returned by Between that include t.
+// It returns an error if the table has no row at t or the column does not exist.
+func (table *Compact[Value]) Set(t time.Time, column int, value Value) error {
+	row, err := table.cellIndex(t, column)
+	if err != nil {
+		return err
+	}
+	table.values[column][row] = value
+	table.setMissing(row, column, false)
+	return nil
+}
+
+// SetMissing marks the cell at time t in column as missing and keeps the value stored in it. It returns the same errors as Set.
+// Tables returned by Between see the mark only when the column already had a missing cell; otherwise they keep seeing the cell as it was.
+func (table *Compact[Value]) SetMissing(t time.Time, column int) error {
+	row, err := table.cellIndex(t, column)
+	if err != nil {
+		return err
+	}
+	table.setMissing(row, column, true)
+	return nil
+}
+
+// cellIndex returns the row at time t after checking that the column exists.
+func (table *Compact[Value]) cellIndex(t time.Time, column int) (int, error) {
+	if table == nil {
+		return 0, errNilTable
+	}
+	if column < 0 || column >= len(table.values) {
+		return 0, fmt.Errorf("column %d out of range for table with %d columns", column, len(table.values))
+	}
+	row, found := slices.BinarySearchFunc(table.times, t, time.Time.Compare)
+	if !found {
+		return 0, fmt.Errorf("no row at %s", t.Format(time.RFC3339))
+	}
+	return row, nil
+}
+
+// AppendRow adds a row after the last row. It returns an error if t is not after LastTime
+// or the number of values does not match the number of columns. A table without rows or
+// columns takes its columns from values.
+//
+// Tables returned by Between never see appended rows. Appending may reallocate the times and
+// values, after which calls to Set are no longer visible through those tables.
+func (table *Compact[Value]) AppendRow(t time.Time, values ...Value) error {
+	if table == nil {
+		return errNilTable
+	}
+	if len(table.times) == 0 && len(table.values) == 0 {
+		table.values = make([][]Value, len(values))
+	}
+	if len(values) != len(table.values) {
+		return fmt.Errorf("got %d values for %d columns", len(values), len(table.values))
+	}
+	if len(table.times) > 0 && !t.After(table.LastTime()) {
+		return fmt.Errorf("row at %s is not after the last row at %s", t.Format(time.RFC3339), table.LastTime().Format(time.RFC3339))
+	}
+	table.times = append(table.times, t)
+	for column, value := range values {
+		table.values[column] = append(table.values[column], value)
+	}
+	for column, missing := range table.missing {
+		if missing != nil {
+			table.missing[column] = append(missing, false)
+		}
+	}
+	return nil
+}
+
+// InsertRow adds a row at t, keeping the rows sorted by time. It returns an error if the table
+// already has a row at t or the number of values does not match the number of columns.
+//
+// InsertRow copies the times and values, so tables returned by Between before the insert
+// no longer share values with the table.
+func (table *Compact[Value]) InsertRow(t time.Time, values ...Value) error {
+	if table == nil {
+		return errNilTable
+	}
+	if len(table.times) == 0 {
+		return table.AppendRow(t, values...)
+	}
+	if len(values) != len(table.values) {
+		return fmt.Errorf("got %d values for %d columns", len(values), len(table.values))
+	}
+	row, found := slices.BinarySearchFunc(table.times, t, time.Time.Compare)
+	if found {
+		return fmt.Errorf("table already has a row at %s", t.Format(time.RFC3339))
+	}
+	table.times = slices.Insert(slices.Clip(table.times), row, t)
+	for column, value := range values {
+		table.values[column] = slices.Insert(slices.Clip(table.values[column]), row, value)
+	}
+	for column, missing := range table.missing {
+		if missing != nil {
+			table.missing[column] = slices.Insert(slices.Clip(missing), row, false)
+		}
+	}
+	return nil
+}
+
+// DeleteColumn removes a column and its name and metadata.
+// The remaining columns keep sharing values with tables returned by Between.
+func (table *Compact[Value]) DeleteColumn(column int) error {
+	if table == nil {
+		return errNilTable
+	}
+	if column < 0 || column >= len(table.values) {
+		return fmt.Errorf("column %d out of range for table with %d columns", column, len(table.values))
+	}
+	table.values = slices.Delete(slices.Clone(table.values), column, column+1)
+	if column < len(table.columns) {
+		table.columns = slices.Delete(slices.Clone(table.columns), column, column+1)
+	}
+	if table.missing != nil {
+		table.missing = compactMask(slices.Delete(slices.Clone(table.missing), column, column+1))
+	}
+	return nil
+}
+
+// DeleteRowsBetween removes the rows from t0 through t1 and returns the number of rows removed.
+// Like Between, the order of t0 and t1 does not matter.
+//
+// When rows are removed, the times and values are copied, so tables returned by Between
+// before the delete no longer share values with the table.
+func (table *Compact[Value]) DeleteRowsBetween(t0, t1 time.Time) int {
+	if table.isEmpty() || len(table.times) == 0 {
+		return 0
+	}
+	first, last := betweenIndexes(table.times, t0, t1, time.Time.Compare)
+	if first >= last {
+		return 0
+	}
+	table.times = slices.Delete(slices.Clone(table.times), first, last)
+	for column := range table.values {
+		table.values[column] = slices.Delete(slices.Clone(table.values[column]), first, last)
+	}
+	if table.missing != nil {
+		missing := make([][]bool, len(table.missing))
+		for column, mask := range table.missing {
+			if mask != nil {
+				missing[column] = slices.Delete(slices.Clone(mask), first, last)
+			}
+		}
+		table.missing = compactMask(missing)
+	}
+	return last - first
+}
+
+// ReorderColumns rearranges the columns so that column i of the result is column order[i] of the table.
+// It returns an error if order is not a permutation of the column indexes.
+// Column values are not copied, so they keep sharing values with tables returned by Between.
+func (table *Compact[Value]) ReorderColumns(order []int) error {
+	if table == nil {
+		return errNilTable
+	}
+	if len(order) != len(table.values) {
+		return fmt.Errorf("got %d column indexes for %d columns", len(order), len(table.values))
+	}
+	seen := make([]bool, len(order))
+	for _, column := range order {
+		if column < 0 || column >= len(order) {
+			return fmt.Errorf("column %d out of range for table with %d columns", column, len(order))
+		}
+		if seen[column] {
+			return fmt.Errorf("column %d appears more than once", column)
+		}
+		seen[column] = true
+	}
+	values := make([][]Value, len(order))
+	var (
+		columns []ColumnInfo
+		missing [][]bool
+	)
+	if len(table.columns) > 0 {
+		columns = make([]ColumnInfo, len(order))
+	}
+	if table.missing != nil {
+		missing = make([][]bool, len(order))
+	}
+	for i, column := range order {
+		values[i] = table.values[column]
+		if columns != nil {
+			columns[i] = table.columnInfo(column)
+		}
+		if missing != nil {
+			missing[i] = table.missing[column]
+		}
+	}
+	table.values, table.columns, table.missing = values, columns, missing
+	return nil
+}
@@ -0,0 +1,158 @@
+package timetable_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/portfoliotree/timetable"
+)
+
+func mutateTable(t *testing.T) *Table {
+	t.Helper()
+	table, err := timetable.NewNamed([]string{"a", "b"},
+		List{elV(day0, 1), elV(day1, 2), elV(day3, 4)},
+		List{elV(day0, 10), elV(day1, 20), elV(day3, 40)},
+	)
+	require.NoError(t, err)
+	return table
+}
+
+func TestCompact_Set(t *testing.T) {
+	t.Run("it is visible through between", func(t *testing.T) {
+		table := mutateTable(t)
+		view := table.Between(date(day1), date(day3))
+		require.NoError(t, table.Set(date(day1), 1, 200))
+		assert.Equal(t, [][]Value{{1, 2, 4}, {10, 200, 40}}, table.Values())
+		assert.Equal(t, [][]Value{{2, 4}, {200, 40}}, view.Values())
+	})
+	t.Run("missing row", func(t *testing.T) {
+		assert.Error(t, mutateTable(t).Set(date(day2), 0, 3))
+	})
+	t.Run("missing column", func(t *testing.T) {
+		assert.Error(t, mutateTable(t).Set(date(day1), 2, 3))
+		assert.Error(t, mutateTable(t).Set(date(day1), -1, 3))
+	})
+	t.Run("nil table", func(t *testing.T) {
+		var table *Table
+		assert.Error(t, table.Set(date(day1), 0, 3))
+	})
+}
+
+func TestCompact_AppendRow(t *testing.T) {
+	t.Run("after the last row", func(t *testing.T) {
+		table := mutateTable(t)
+		view := table.Between(date(day0), date(day3))
+		require.NoError(t, table.AppendRow(date(dayAfter), 5, 50))
+		assert.Equal(t, []time.Time{date(day0), date(day1), date(day3), date(dayAfter)}, table.Times())
+		assert.Equal(t, [][]Value{{1, 2, 4, 5}, {10, 20, 40, 50}}, table.Values())
+		assert.Equal(t, 3, view.NumberOfRows(), "views do not see appended rows")
+	})
+	t.Run("out of order", func(t *testing.T) {
+		table := mutateTable(t)
+		assert.Error(t, table.AppendRow(date(day2), 3, 30))
+		assert.Error(t, table.AppendRow(date(day3), 3, 30))
+		assert.Equal(t, 3, table.NumberOfRows())
+	})
+	t.Run("wrong number of values", func(t *testing.T) {
+		assert.Error(t, mutateTable(t).AppendRow(date(dayAfter), 5))
+	})
+	t.Run("empty table", func(t *testing.T) {
+		table := new(Table)
+		require.NoError(t, table.AppendRow(date(day0), 1, 10))
+		require.NoError(t, table.AppendRow(date(day1), 2, 20))
+		assert.Equal(t, [][]Value{{1, 2}, {10, 20}}, table.Values())
+	})
+	t.Run("does not write into a view of a larger table", func(t *testing.T) {
+		table := mutateTable(t)
+		view := table.Between(date(day0), date(day1))
+		require.NoError(t, view.AppendRow(date(day2), 3, 30))
+		assert.Equal(t, [][]Value{{1, 2, 3}, {10, 20, 30}}, view.Values())
+		assert.Equal(t, [][]Value{{1, 2, 4}, {10, 20, 40}}, table.Values())
+	})
+}
+
+func TestCompact_InsertRow(t *testing.T) {
+	t.Run("between rows", func(t *testing.T) {
+		table := mutateTable(t)
+		view := table.Between(date(day0), date(day3))
+		require.NoError(t, table.InsertRow(date(day2), 3, 30))
+		assert.Equal(t, []time.Time{date(day0), date(day1), date(day2), date(day3)}, table.Times())
+		assert.Equal(t, [][]Value{{1, 2, 3, 4}, {10, 20, 30, 40}}, table.Values())
+		assert.Equal(t, [][]Value{{1, 2, 4}, {10, 20, 40}}, view.Values(), "views are unchanged")
+
+		require.NoError(t, table.Set(date(day1), 0, 200))
+		assert.Equal(t, [][]Value{{1, 2, 4}, {10, 20, 40}}, view.Values(), "views no longer share values")
+	})
+	t.Run("before the first row", func(t *testing.T) {
+		table := mutateTable(t)
+		require.NoError(t, table.InsertRow(date(dayBefore), 0, 0))
+		assert.Equal(t, date(dayBefore), table.FirstTime())
+	})
+	t.Run("existing time", func(t *testing.T) {
+		assert.Error(t, mutateTable(t).InsertRow(date(day1), 3, 30))
+	})
+	t.Run("wrong number of values", func(t *testing.T) {
+		assert.Error(t, mutateTable(t).InsertRow(date(day2), 3, 30, 300))
+	})
+}
+
+func TestCompact_DeleteColumn(t *testing.T) {
+	table := mutateTable(t)
+	view := table.Between(date(day0), date(day3))
+	require.NoError(t, table.DeleteColumn(0))
+	assert.Equal(t, [][]Value{{10, 20, 40}}, table.Values())
+	assert.Equal(t, []string{"b"}, table.ColumnNames())
+	assert.Equal(t, 2, view.NumberOfColumns())
+
+	require.NoError(t, table.Set(date(day1), 0, 200))
+	assert.Equal(t, [][]Value{{1, 2, 4}, {10, 200, 40}}, view.Values(), "remaining columns share values")
+
+	assert.Error(t, table.DeleteColumn(1))
+}
+
+func TestCompact_DeleteRowsBetween(t *testing.T) {
+	for _, tt := range []struct {
+		Name    string
+		T0, T1  time.Time
+		Removed int
+		Times   []time.Time
+	}{
+		{Name: "middle", T0: date(day1), T1: date(day2), Removed: 1, Times: []time.Time{date(day0), date(day3)}},
+		{Name: "reversed", T0: date(day3), T1: date(day1), Removed: 2, Times: []time.Time{date(day0)}},
+		{Name: "all", T0: date(dayBefore), T1: date(dayAfter), Removed: 3, Times: []time.Time{}},
+		{Name: "none", T0: date(dayAfter), T1: date(dayAfter).AddDate(0, 0, 1), Removed: 0, Times: []time.Time{date(day0), date(day1), date(day3)}},
+	} {
+		t.Run(tt.Name, func(t *testing.T) {
+			table := mutateTable(t)
+			view := table.Between(date(day0), date(day3))
+			assert.Equal(t, tt.Removed, table.DeleteRowsBetween(tt.T0, tt.T1))
+			assert.Equal(t, tt.Times, table.Times())
+			assert.Equal(t, 3, view.NumberOfRows())
+		})
+	}
+
+	t.Run("nil table", func(t *testing.T) {
+		var table *Table
+		assert.Zero(t, table.DeleteRowsBetween(date(day0), date(day3)))
+	})
+}
+
+func TestCompact_ReorderColumns(t *testing.T) {
+	table := mutateTable(t)
+	require.NoError(t, table.AppendRow(date(dayAfter), 5, 50))
+	require.NoError(t, table.ReorderColumns([]int{1, 0}))
+	assert.Equal(t, [][]Value{{10, 20, 40, 50}, {1, 2, 4, 5}}, table.Values())
+	assert.Equal(t, []string{"b", "a"}, table.ColumnNames())
+
+	assert.Error(t, table.ReorderColumns([]int{0}))
+	assert.Error(t, table.ReorderColumns([]int{0, 0}))
+	assert.Error(t, table.ReorderColumns([]int{0, 2}))
+
+	unnamed := timetable.New(List{elV(day0, 1)}, List{elV(day0, 2)})
+	require.NoError(t, unnamed.ReorderColumns([]int{1, 0}))
+	assert.Equal(t, [][]Value{{2}, {1}}, unnamed.Values())
+	assert.Equal(t, []string{"", ""}, unnamed.ColumnNames())
+}
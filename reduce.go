@@ -0,0 +1,38 @@
+package timetable
+
+// ReduceColumns combines the values of each column over all rows using aggregate and returns one value per column.
+// Missing cells are left out. A column where every cell is missing reduces to the zero value.
+func (table *Compact[Value]) ReduceColumns(aggregate Aggregation[Value]) []Value {
+	result := make([]Value, len(table.values))
+	buffer := make([]Value, 0, len(table.times))
+	for column, values := range table.values {
+		if mask := table.missingColumn(column); mask != nil {
+			if values = withoutMissing(buffer[:0], values, mask); len(values) == 0 {
+				continue
+			}
+		}
+		result[column] = aggregate(values)
+	}
+	return result
+}
+
+// ReduceRows combines the values of each row across columns using aggregate and returns a list keyed by the times of the table.
+// Missing cells are left out. A row where every cell is missing gives a missing cell.
+func (table *Compact[Value]) ReduceRows(aggregate Aggregation[Value]) List[Value] {
+	result := make(List[Value], len(table.times))
+	row := make([]Value, 0, len(table.values))
+	for index, t := range table.times {
+		row = row[:0]
+		for column := range table.values {
+			if !table.IsMissing(index, column) {
+				row = append(row, table.values[column][index])
+			}
+		}
+		if len(row) == 0 {
+			result[index] = NewMissingCell[Value](t)
+			continue
+		}
+		result[index] = Cell[Value]{time: t, value: aggregate(row)}
+	}
+	return result
+}
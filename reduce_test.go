@@ -0,0 +1,59 @@
+package timetable_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/portfoliotree/timetable"
+)
+
+func TestCompact_ReduceColumns(t *testing.T) {
+	table := timetable.New(
+		List{elV(day0, 1), elV(day1, 2), elV(day2, 3)},
+		List{elV(day0, 10), elV(day1, 20), elV(day2, 30)},
+	)
+	assert.Equal(t, []Value{6, 60}, table.ReduceColumns(timetable.Sum[Value]))
+	assert.Equal(t, []Value{3, 30}, table.ReduceColumns(timetable.Last[Value]))
+
+	t.Run("missing cells are left out", func(t *testing.T) {
+		assert.Equal(t, []Value{2, 25}, maskedTable().ReduceColumns(timetable.Mean[Value]))
+	})
+
+	t.Run("every cell missing", func(t *testing.T) {
+		masked := timetable.New(List{elV(day0, 1)}).AddColumnWithJoin(List{elV(day1, 2)}, nil, timetable.JoinLeft)
+		assert.Equal(t, []Value{1, 0}, masked.ReduceColumns(timetable.Max[Value]))
+	})
+
+	t.Run("returns", func(t *testing.T) {
+		returns := timetable.New(timetable.List[float64]{
+			timetable.NewCell(date(day0), 0.1),
+			timetable.NewCell(date(day1), -0.5),
+			timetable.NewCell(date(day2), 0.2),
+		})
+		assert.InDeltaSlice(t, []float64{1.1*0.5*1.2 - 1}, returns.ReduceColumns(timetable.CompoundReturn[float64]), 1e-12)
+		assert.InDeltaSlice(t, []float64{-0.5}, returns.ReduceColumns(timetable.MaxDrawdown[float64]), 1e-12)
+	})
+}
+
+func TestCompact_ReduceRows(t *testing.T) {
+	table := timetable.New(
+		List{elV(day0, 1), elV(day1, 2)},
+		List{elV(day0, 10), elV(day1, 20)},
+	)
+	assert.Equal(t, List{elV(day0, 11), elV(day1, 22)}, table.ReduceRows(timetable.Sum[Value]))
+
+	t.Run("missing cells are left out", func(t *testing.T) {
+		assert.Equal(t, List{elV(day0, 5), elV(day1, 2), elV(day2, 3), elV(day3, 22)}, maskedTable().ReduceRows(timetable.Mean[Value]))
+	})
+
+	t.Run("every cell missing", func(t *testing.T) {
+		masked := timetable.New(List{elV(day0, 1), elV(day1, 2)}).AddColumnWithJoin(List{elV(day0, 2)}, nil, timetable.JoinLeft)
+		_ = masked.SetMissing(date(day1), 0)
+		assert.Equal(t, List{elV(day0, 2), timetable.NewMissingCell[Value](date(day1))}, masked.ReduceRows(timetable.Max[Value]))
+	})
+
+	t.Run("no rows", func(t *testing.T) {
+		assert.Empty(t, timetable.New(List{}).ReduceRows(timetable.Sum[Value]))
+	})
+}
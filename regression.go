@@ -0,0 +1,206 @@
+package timetable
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// Regression is the result of an ordinary least squares regression of a dependent column on one or more regressor columns.
+// Fields are NaN when there are too few observations or the regressors are collinear.
+type Regression[Value Float] struct {
+	// Alpha is the intercept.
+	Alpha Value
+
+	// Betas holds a slope for each regressor in the order the regressors were given.
+	Betas []Value
+
+	// RSquared is the share of the variance of the dependent column explained by the regressors.
+	RSquared Value
+
+	// TrackingError is the sample standard deviation of the dependent column minus the first regressor, the benchmark.
+	// It is per period and not annualized.
+	TrackingError Value
+
+	// Observations is the number of rows used.
+	Observations int
+}
+
+// OLS regresses the dependent column on the regressor columns with an intercept.
+// Observations are aligned by row, and rows where any of the columns is missing or NaN are skipped.
+func OLS[Value Float](table *Compact[Value], dependent int, regressors ...int) (Regression[Value], error) {
+	if err := checkRegressionColumns(table, dependent, regressors); err != nil {
+		return Regression[Value]{}, err
+	}
+	return regress(table, dependent, regressors, regressionRows(table, dependent, regressors, 0, len(table.times))), nil
+}
+
+// RollingOLS is like OLS for each window of rows, keyed by the time of the last row in the window.
+// Rows where the window has fewer usable rows than its minimum observations hold a missing cell.
+func RollingOLS[Value Float](table *Compact[Value], window Window, dependent int, regressors ...int) (List[Regression[Value]], error) {
+	if err := checkRegressionColumns(table, dependent, regressors); err != nil {
+		return nil, err
+	}
+	result := make(List[Regression[Value]], len(table.times))
+	minObservations := window.minObservations()
+	start := 0
+	for end, t := range table.times {
+		for start < end && window.excludes(table.times, start, end) {
+			start++
+		}
+		rows := regressionRows(table, dependent, regressors, start, end+1)
+		if len(rows) < minObservations {
+			result[end] = NewMissingCell[Regression[Value]](t)
+			continue
+		}
+		result[end] = NewCell(t, regress(table, dependent, regressors, rows))
+	}
+	return result, nil
+}
+
+func checkRegressionColumns[Value any](table *Compact[Value], dependent int, regressors []int) error {
+	if len(regressors) == 0 {
+		return errors.New("at least one regressor column is required")
+	}
+	for _, column := range append([]int{dependent}, regressors...) {
+		if column < 0 || column >= table.NumberOfColumns() {
+			return fmt.Errorf("column %d out of range", column)
+		}
+	}
+	return nil
+}
+
+// regressionRows returns the rows from start to end where the dependent and every regressor are neither missing nor NaN.
+func regressionRows[Value Float](table *Compact[Value], dependent int, regressors []int, start, end int) []int {
+	var rows []int
+	usable := func(column, row int) bool {
+		return !table.IsMissing(row, column) && !math.IsNaN(float64(table.values[column][row]))
+	}
+rows:
+	for row := start; row < end; row++ {
+		if !usable(dependent, row) {
+			continue
+		}
+		for _, column := range regressors {
+			if !usable(column, row) {
+				continue rows
+			}
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+// regress solves the normal equations of the centered columns for the given rows.
+func regress[Value Float](table *Compact[Value], dependent int, regressors []int, rows []int) Regression[Value] {
+	k, n := len(regressors), len(rows)
+	result := Regression[Value]{Alpha: Value(math.NaN()), Betas: make([]Value, k), RSquared: Value(math.NaN()), TrackingError: Value(math.NaN()), Observations: n}
+	for i := range result.Betas {
+		result.Betas[i] = Value(math.NaN())
+	}
+	if n >= 2 {
+		active := make([]float64, n)
+		for i, row := range rows {
+			active[i] = float64(table.values[dependent][row] - table.values[regressors[0]][row])
+		}
+		result.TrackingError = Value(Std(active))
+	}
+	if n < k+2 {
+		return result
+	}
+
+	y := make([]float64, n)
+	x := make([][]float64, k)
+	for i, row := range rows {
+		y[i] = float64(table.values[dependent][row])
+	}
+	for j, column := range regressors {
+		x[j] = make([]float64, n)
+		for i, row := range rows {
+			x[j][i] = float64(table.values[column][row])
+		}
+	}
+	meanY := Mean(y)
+	meansX := make([]float64, k)
+	for j := range x {
+		meansX[j] = Mean(x[j])
+	}
+
+	// The normal equations are the k by k matrix of centered cross products augmented with the cross products with y.
+	system := make([][]float64, k)
+	for a := range system {
+		system[a] = make([]float64, k+1)
+		for b := range k {
+			for i := range n {
+				system[a][b] += (x[a][i] - meansX[a]) * (x[b][i] - meansX[b])
+			}
+		}
+		for i := range n {
+			system[a][k] += (x[a][i] - meansX[a]) * (y[i] - meanY)
+		}
+	}
+	betas, ok := solve(system)
+	if !ok {
+		return result
+	}
+
+	alpha := meanY
+	for j, beta := range betas {
+		alpha -= beta * meansX[j]
+	}
+	var residualSquares, totalSquares float64
+	for i := range n {
+		fitted := alpha
+		for j, beta := range betas {
+			fitted += beta * x[j][i]
+		}
+		residualSquares += (y[i] - fitted) * (y[i] - fitted)
+		totalSquares += (y[i] - meanY) * (y[i] - meanY)
+	}
+
+	result.Alpha = Value(alpha)
+	for j, beta := range betas {
+		result.Betas[j] = Value(beta)
+	}
+	if totalSquares > 0 {
+		result.RSquared = Value(1 - residualSquares/totalSquares)
+	}
+	return result
+}
+
+// solve solves an augmented system of linear equations in place by Gaussian elimination with partial pivoting.
+// It returns false when the system is singular, judged relative to the largest value on its diagonal.
+func solve(system [][]float64) ([]float64, bool) {
+	k := len(system)
+	var scale float64
+	for i := range k {
+		scale = max(scale, math.Abs(system[i][i]))
+	}
+	for pivot := range k {
+		best := pivot
+		for row := pivot + 1; row < k; row++ {
+			if math.Abs(system[row][pivot]) > math.Abs(system[best][pivot]) {
+				best = row
+			}
+		}
+		if math.Abs(system[best][pivot]) <= 1e-12*scale {
+			return nil, false
+		}
+		system[pivot], system[best] = system[best], system[pivot]
+		for row := pivot + 1; row < k; row++ {
+			factor := system[row][pivot] / system[pivot][pivot]
+			for column := pivot; column <= k; column++ {
+				system[row][column] -= factor * system[pivot][column]
+			}
+		}
+	}
+	solution := make([]float64, k)
+	for row := k - 1; row >= 0; row-- {
+		sum := system[row][k]
+		for column := row + 1; column < k; column++ {
+			sum -= system[row][column] * solution[column]
+		}
+		solution[row] = sum / system[row][row]
+	}
+	return solution, true
+}
@@ -0,0 +1,111 @@
+package timetable_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/portfoliotree/timetable"
+)
+
+func TestOLS(t *testing.T) {
+	t.Run("one regressor", func(t *testing.T) {
+		table := floatTable(t, []string{"fund", "benchmark"}, []float64{3, 5, 7, 9}, []float64{1, 2, 3, 4})
+		regression, err := timetable.OLS(table, 0, 1)
+		require.NoError(t, err)
+		assert.Equal(t, 4, regression.Observations)
+		assert.InDelta(t, 1, regression.Alpha, 1e-12)
+		require.Len(t, regression.Betas, 1)
+		assert.InDelta(t, 2, regression.Betas[0], 1e-12)
+		assert.InDelta(t, 1, regression.RSquared, 1e-12)
+		assert.InDelta(t, math.Sqrt(5.0/3), regression.TrackingError, 1e-12)
+	})
+
+	t.Run("two regressors", func(t *testing.T) {
+		table := floatTable(t, nil, []float64{6, 5, 10, 9, 17}, []float64{1, 2, 3, 4, 5}, []float64{1, 0, 1, 0, 2})
+		regression, err := timetable.OLS(table, 0, 1, 2)
+		require.NoError(t, err)
+		assert.InDelta(t, 1, regression.Alpha, 1e-9)
+		assert.InDeltaSlice(t, []float64{2, 3}, regression.Betas, 1e-9)
+		assert.InDelta(t, 1, regression.RSquared, 1e-9)
+	})
+
+	t.Run("imperfect fit", func(t *testing.T) {
+		table := floatTable(t, nil, []float64{1, 3, 2, 4}, []float64{1, 2, 3, 4})
+		regression, err := timetable.OLS(table, 0, 1)
+		require.NoError(t, err)
+		assert.InDelta(t, 0.8, regression.Betas[0], 1e-12)
+		assert.InDelta(t, 0.5, regression.Alpha, 1e-12)
+		assert.InDelta(t, 0.64, regression.RSquared, 1e-12)
+	})
+
+	t.Run("missing and NaN rows are skipped", func(t *testing.T) {
+		table := floatTable(t, nil, []float64{3, 5, math.NaN(), 9, 100}, []float64{1, 2, 3, 4, 5})
+		require.NoError(t, table.SetMissing(date(dayAfter), 1))
+		regression, err := timetable.OLS(table, 0, 1)
+		require.NoError(t, err)
+		assert.Equal(t, 3, regression.Observations)
+		assert.InDelta(t, 1, regression.Alpha, 1e-12)
+		assert.InDelta(t, 2, regression.Betas[0], 1e-12)
+	})
+
+	t.Run("collinear regressors", func(t *testing.T) {
+		table := floatTable(t, nil, []float64{1, 3, 2, 4}, []float64{1, 2, 3, 4}, []float64{2, 4, 6, 8})
+		regression, err := timetable.OLS(table, 0, 1, 2)
+		require.NoError(t, err)
+		assert.True(t, math.IsNaN(regression.Alpha))
+		assert.True(t, math.IsNaN(regression.Betas[0]))
+		assert.True(t, math.IsNaN(regression.RSquared))
+	})
+
+	t.Run("too few rows", func(t *testing.T) {
+		table := floatTable(t, nil, []float64{1, 2}, []float64{1, 3})
+		regression, err := timetable.OLS(table, 0, 1)
+		require.NoError(t, err)
+		assert.True(t, math.IsNaN(regression.Betas[0]))
+		assert.InDelta(t, math.Sqrt(0.5), regression.TrackingError, 1e-12)
+	})
+
+	t.Run("bad columns", func(t *testing.T) {
+		table := floatTable(t, nil, []float64{1, 2}, []float64{1, 3})
+		_, err := timetable.OLS(table, 0)
+		assert.Error(t, err)
+		_, err = timetable.OLS(table, 0, 2)
+		assert.Error(t, err)
+		_, err = timetable.OLS(table, -1, 1)
+		assert.Error(t, err)
+	})
+}
+
+func TestRollingOLS(t *testing.T) {
+	table := floatTable(t, nil, []float64{3, 5, 7, 10}, []float64{1, 2, 3, 4})
+	regressions, err := timetable.RollingOLS(table, timetable.RowWindow(3), 0, 1)
+	require.NoError(t, err)
+	require.Len(t, regressions, 4)
+	assert.True(t, regressions[0].IsMissing())
+	assert.True(t, regressions[1].IsMissing())
+	assert.Equal(t, date(day2), regressions[2].Time())
+	assert.InDelta(t, 2, regressions[2].Value().Betas[0], 1e-12)
+	assert.InDelta(t, 2.5, regressions[3].Value().Betas[0], 1e-12)
+	assert.InDelta(t, -1.0/6, regressions[3].Value().Alpha, 1e-12)
+
+	t.Run("missing rows do not count as observations", func(t *testing.T) {
+		table := floatTable(t, nil, []float64{3, 5, 7, 9}, []float64{1, 2, 3, 4})
+		require.NoError(t, table.SetMissing(date(day1), 0))
+		regressions, err := timetable.RollingOLS(table, timetable.RowWindow(3), 0, 1)
+		require.NoError(t, err)
+		assert.True(t, regressions[2].IsMissing())
+		assert.True(t, regressions[3].IsMissing())
+
+		window := timetable.Window{Rows: 3, MinObservations: 2}
+		regressions, err = timetable.RollingOLS(table, window, 0, 1)
+		require.NoError(t, err)
+		assert.False(t, regressions[2].IsMissing())
+		assert.Equal(t, 2, regressions[3].Value().Observations)
+	})
+
+	_, err = timetable.RollingOLS(table, timetable.RowWindow(3), 0)
+	assert.Error(t, err)
+}
@@ -0,0 +1,99 @@
+package timetable
+
+import (
+	"slices"
+	"time"
+)
+
+// Grid returns the times from start to end inclusive, step apart. It returns nil when step is not positive.
+// For grids of trading days or calendar periods use Calendar.TradingDays or Frequency.Periods.
+func Grid(start, end time.Time, step time.Duration) []time.Time {
+	if step <= 0 {
+		return nil
+	}
+	var grid []time.Time
+	for t := start; !t.After(end); t = t.Add(step) {
+		grid = append(grid, t)
+	}
+	return grid
+}
+
+// RegularizeOptions configures Regularize.
+// The zero value samples the last cell at or before each grid time and leaves grid times without one missing.
+type RegularizeOptions[Value any] struct {
+	// Aggregate combines the cells that land in each grid interval. Each grid time combines the cells after
+	// the previous grid time up to and including it; the first grid time combines every cell at or before it.
+	// Grid times without any cell are missing.
+	// When it is nil, each grid time takes the value of the last cell at or before it.
+	Aggregate Aggregation[Value]
+
+	// MaxAge limits how old a cell may be when it is sampled without Aggregate.
+	// Grid times where the last cell is more than MaxAge old are missing. Zero means there is no limit.
+	MaxAge time.Duration
+
+	// Fill fills the missing grid times after sampling or aggregation. Grid times it leaves unfilled stay missing.
+	Fill Filler[Value]
+}
+
+// Regularize places the cells of a list onto the grid, returning one cell for each grid time.
+// Missing cells of the list are ignored. The grid is sorted and repeated times are dropped.
+// The list is not modified.
+func Regularize[Value any](list List[Value], grid []time.Time, options RegularizeOptions[Value]) List[Value] {
+	times, values, missing := regularize(list, grid, options)
+	result := make(List[Value], len(times))
+	for i := range result {
+		result[i] = Cell[Value]{time: times[i], value: values[i], missing: missing[i]}
+	}
+	return result
+}
+
+// RegularizeTable is like Regularize but returns a table with one column whose rows are the grid times.
+func RegularizeTable[Value any](list List[Value], grid []time.Time, options RegularizeOptions[Value]) *Compact[Value] {
+	times, values, missing := regularize(list, grid, options)
+	return &Compact[Value]{times: times, values: [][]Value{values}, missing: compactMask([][]bool{missing})}
+}
+
+func regularize[Value any](list List[Value], grid []time.Time, options RegularizeOptions[Value]) ([]time.Time, []Value, []bool) {
+	grid = slices.Clone(grid)
+	slices.SortFunc(grid, time.Time.Compare)
+	grid = slices.CompactFunc(grid, time.Time.Equal)
+
+	cells := make(List[Value], 0, len(list))
+	for _, cell := range list {
+		if !cell.missing {
+			cells = append(cells, cell)
+		}
+	}
+	slices.SortStableFunc(cells, Cell[Value].compareTimes)
+
+	values := make([]Value, len(grid))
+	missing := make([]bool, len(grid))
+	group := make([]Value, 0, len(cells))
+	next := 0
+	for i, t := range grid {
+		start := next
+		for next < len(cells) && !cells[next].time.After(t) {
+			next++
+		}
+		switch {
+		case options.Aggregate != nil:
+			group = group[:0]
+			for _, cell := range cells[start:next] {
+				group = append(group, cell.value)
+			}
+			if len(group) == 0 {
+				missing[i] = true
+				continue
+			}
+			values[i] = options.Aggregate(group)
+		case next == 0 || (options.MaxAge > 0 && t.Sub(cells[next-1].time) > options.MaxAge):
+			missing[i] = true
+		default:
+			values[i] = cells[next-1].value
+		}
+	}
+	if options.Fill != nil && slices.Contains(missing, true) {
+		options.Fill(grid, values, missing)
+	}
+	return grid, values, missing
+}
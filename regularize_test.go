@@ -0,0 +1,120 @@
+package timetable_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/portfoliotree/timetable"
+)
+
+func TestGrid(t *testing.T) {
+	start := date(day0)
+	assert.Equal(t, []time.Time{start, start.Add(time.Hour), start.Add(2 * time.Hour)}, timetable.Grid(start, start.Add(150*time.Minute), time.Hour))
+	assert.Nil(t, timetable.Grid(start, start.Add(time.Hour), 0))
+	assert.Nil(t, timetable.Grid(start, start.Add(-time.Hour), time.Hour))
+}
+
+func TestRegularize(t *testing.T) {
+	start := date(day0)
+	at := func(d time.Duration) time.Time { return start.Add(d) }
+	ticks := List{
+		timetable.NewCell(at(time.Hour), 1),
+		timetable.NewCell(at(90*time.Minute), 2),
+		timetable.NewCell(at(105*time.Minute), 4),
+		timetable.NewCell(at(190*time.Minute), 3),
+		timetable.NewMissingCell[Value](at(4 * time.Hour)),
+		timetable.NewCell(at(5*time.Hour), 5),
+	}
+	grid := timetable.Grid(at(time.Hour), at(5*time.Hour), time.Hour)
+
+	for _, tt := range []struct {
+		Name    string
+		Options timetable.RegularizeOptions[Value]
+		Values  []Value
+		Missing []bool
+	}{
+		{
+			Name:    "as of",
+			Values:  []Value{1, 4, 4, 3, 5},
+			Missing: []bool{false, false, false, false, false},
+		},
+		{
+			Name:    "max age",
+			Options: timetable.RegularizeOptions[Value]{MaxAge: 30 * time.Minute},
+			Values:  []Value{1, 4, 0, 0, 5},
+			Missing: []bool{false, false, true, true, false},
+		},
+		{
+			Name:    "gaps longer than the fill limit stay missing",
+			Options: timetable.RegularizeOptions[Value]{MaxAge: 30 * time.Minute, Fill: timetable.ForwardFill[Value](1)},
+			Values:  []Value{1, 4, 0, 0, 5},
+			Missing: []bool{false, false, true, true, false},
+		},
+		{
+			Name:    "aggregate",
+			Options: timetable.RegularizeOptions[Value]{Aggregate: timetable.Sum[Value]},
+			Values:  []Value{1, 6, 0, 3, 5},
+			Missing: []bool{false, false, true, false, false},
+		},
+		{
+			Name:    "aggregate with fill",
+			Options: timetable.RegularizeOptions[Value]{Aggregate: timetable.Sum[Value], Fill: timetable.ConstantFill[Value](0)},
+			Values:  []Value{1, 6, 0, 3, 5},
+			Missing: []bool{false, false, false, false, false},
+		},
+	} {
+		t.Run(tt.Name, func(t *testing.T) {
+			result := timetable.Regularize(ticks, grid, tt.Options)
+			require.Len(t, result, len(grid))
+			for i, cell := range result {
+				assert.Equal(t, grid[i], cell.Time())
+				assert.Equal(t, tt.Values[i], cell.Value(), "value at %d", i)
+				assert.Equal(t, tt.Missing[i], cell.IsMissing(), "missing at %d", i)
+			}
+		})
+	}
+
+	t.Run("fill fills gaps it can", func(t *testing.T) {
+		options := timetable.RegularizeOptions[Value]{MaxAge: 30 * time.Minute, Fill: timetable.ForwardFill[Value](0)}
+		result := timetable.Regularize(ticks, grid, options)
+		assert.Equal(t, 4, result[3].Value())
+		assert.False(t, result[3].IsMissing())
+	})
+
+	t.Run("before the first cell", func(t *testing.T) {
+		result := timetable.Regularize(ticks, []time.Time{start, at(time.Hour)}, timetable.RegularizeOptions[Value]{})
+		assert.True(t, result[0].IsMissing())
+		assert.Equal(t, 1, result[1].Value())
+	})
+
+	t.Run("unsorted list and grid", func(t *testing.T) {
+		unsorted := List{ticks[3], ticks[0], ticks[2], ticks[1]}
+		result := timetable.Regularize(unsorted, []time.Time{grid[1], grid[0], grid[1]}, timetable.RegularizeOptions[Value]{})
+		require.Len(t, result, 2)
+		assert.Equal(t, grid[0], result[0].Time())
+		assert.Equal(t, 4, result[1].Value())
+		assert.Equal(t, ticks[3], unsorted[0], "the list is not modified")
+	})
+
+	t.Run("trading days", func(t *testing.T) {
+		list := List{elV(day0, 1), elV("2022-10-22", 2), elV(day3, 3)}
+		result := timetable.Regularize(list, timetable.WeekdayCalendar().TradingDays(date(day0), date(day3)), timetable.RegularizeOptions[Value]{})
+		assert.Equal(t, List{elV(day0, 1), elV(day1, 1), elV(day2, 2), elV(day3, 3)}, result)
+	})
+}
+
+func TestRegularizeTable(t *testing.T) {
+	list := List{elV(day0, 1), elV(day3, 3)}
+	grid := dates(day0, day1, day2, day3)
+	table := timetable.RegularizeTable(list, grid, timetable.RegularizeOptions[Value]{Aggregate: timetable.Sum[Value]})
+	assert.Equal(t, grid, table.Times())
+	assert.Equal(t, [][]Value{{1, 0, 0, 3}}, table.Values())
+	assert.Equal(t, [][]bool{{false, true, true, false}}, missingCells(table))
+
+	filled := timetable.RegularizeTable(list, grid, timetable.RegularizeOptions[Value]{})
+	assert.Equal(t, [][]Value{{1, 1, 1, 3}}, filled.Values())
+	assert.False(t, filled.HasMissing())
+}
@@ -0,0 +1,139 @@
+package timetable
+
+import (
+	"slices"
+	"time"
+)
+
+// Period is a calendar period used to group rows.
+type Period int
+
+const (
+	// Week periods start on Monday, following ISO 8601.
+	Week Period = iota + 1
+	Month
+	Quarter
+	Year
+)
+
+func (period Period) String() string {
+	switch period {
+	case Week:
+		return "week"
+	case Month:
+		return "month"
+	case Quarter:
+		return "quarter"
+	case Year:
+		return "year"
+	default:
+		return "unknown"
+	}
+}
+
+// Start returns the first instant of the period containing t in the location loc.
+// A nil loc means the location of t.
+func (period Period) Start(t time.Time, loc *time.Location) time.Time {
+	if loc != nil {
+		t = t.In(loc)
+	}
+	year, month, day := t.Date()
+	switch period {
+	case Week:
+		day -= (int(t.Weekday()) + 6) % 7
+	case Month:
+		day = 1
+	case Quarter:
+		month, day = month-(month-1)%3, 1
+	case Year:
+		month, day = time.January, 1
+	}
+	return time.Date(year, month, day, 0, 0, 0, 0, t.Location())
+}
+
+// Resample groups rows by period and combines the values of each column in a group using aggregate.
+// Each row of the result is keyed by the time of the last row in its group,
+// so a month-end table of trading days keeps the last trading day of each month.
+// Period boundaries are computed in loc; a nil loc uses the location of each time.
+// Missing cells are left out of each group; a group where every cell is missing gives a missing cell.
+func (table *Compact[Value]) Resample(period Period, loc *time.Location, aggregate Aggregation[Value]) *Compact[Value] {
+	buckets := periodBuckets(table.times, period, loc)
+	times := make([]time.Time, len(buckets))
+	values := make([][]Value, len(table.values))
+	masks := make([][]bool, len(table.values))
+	for column := range values {
+		values[column] = make([]Value, len(buckets))
+		masks[column] = make([]bool, len(buckets))
+	}
+	present := make([]Value, 0, len(table.times))
+	for i, bucket := range buckets {
+		times[i] = table.times[bucket.end-1]
+		for column := range values {
+			group := table.values[column][bucket.start:bucket.end]
+			if mask := table.missingColumn(column); mask != nil {
+				if group = withoutMissing(present[:0], group, mask[bucket.start:bucket.end]); len(group) == 0 {
+					masks[column][i] = true
+					continue
+				}
+			}
+			values[column][i] = aggregate(group)
+		}
+	}
+	return &Compact[Value]{times: times, values: values, columns: slices.Clone(table.columns), missing: compactMask(masks)}
+}
+
+// Resample is like Compact.Resample. The list is sorted in place first.
+func (list List[Value]) Resample(period Period, loc *time.Location, aggregate Aggregation[Value]) List[Value] {
+	slices.SortFunc(list, Cell[Value].compareTimes)
+	times := make([]time.Time, len(list))
+	for i, cell := range list {
+		times[i] = cell.time
+	}
+	buckets := periodBuckets(times, period, loc)
+	result := make(List[Value], len(buckets))
+	values := make([]Value, 0, len(list))
+	for i, bucket := range buckets {
+		values = values[:0]
+		for _, cell := range list[bucket.start:bucket.end] {
+			if !cell.missing {
+				values = append(values, cell.value)
+			}
+		}
+		if len(values) == 0 {
+			result[i] = NewMissingCell[Value](times[bucket.end-1])
+			continue
+		}
+		result[i] = Cell[Value]{time: times[bucket.end-1], value: aggregate(values)}
+	}
+	return result
+}
+
+// withoutMissing appends the values that are not missing to buffer.
+func withoutMissing[Value any](buffer, values []Value, missing []bool) []Value {
+	for i, value := range values {
+		if !missing[i] {
+			buffer = append(buffer, value)
+		}
+	}
+	return buffer
+}
+
+type bucket struct{ start, end int }
+
+// periodBuckets splits sorted times into runs that fall in the same period.
+func periodBuckets(times []time.Time, period Period, loc *time.Location) []bucket {
+	var (
+		buckets []bucket
+		current time.Time
+	)
+	for i, t := range times {
+		start := period.Start(t, loc)
+		if i == 0 || !start.Equal(current) {
+			buckets = append(buckets, bucket{start: i, end: i + 1})
+			current = start
+		} else {
+			buckets[len(buckets)-1].end = i + 1
+		}
+	}
+	return buckets
+}
@@ -0,0 +1,71 @@
+package timetable_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/portfoliotree/timetable"
+)
+
+func TestPeriod_Start(t *testing.T) {
+	for _, tt := range []struct {
+		Period timetable.Period
+		Time   string
+		Start  string
+	}{
+		{Period: timetable.Week, Time: day0, Start: "2022-10-17"},
+		{Period: timetable.Week, Time: "2022-10-23", Start: "2022-10-17"},
+		{Period: timetable.Week, Time: day2, Start: day2},
+		{Period: timetable.Month, Time: day0, Start: "2022-10-01"},
+		{Period: timetable.Quarter, Time: day0, Start: "2022-10-01"},
+		{Period: timetable.Quarter, Time: "2022-09-30", Start: "2022-07-01"},
+		{Period: timetable.Year, Time: day0, Start: "2022-01-01"},
+	} {
+		t.Run(tt.Period.String()+" "+tt.Time, func(t *testing.T) {
+			assert.Equal(t, date(tt.Start), tt.Period.Start(date(tt.Time), nil))
+		})
+	}
+}
+
+func TestCompact_Resample(t *testing.T) {
+	table := timetable.New(
+		List{elV("2022-01-28", 1), elV("2022-01-31", 2), elV("2022-02-01", 3), elV("2022-02-28", 4), elV("2022-04-01", 5)},
+		List{elV("2022-01-28", 10), elV("2022-01-31", 20), elV("2022-02-01", 30), elV("2022-02-28", 40), elV("2022-04-01", 50)},
+	)
+
+	t.Run("month end", func(t *testing.T) {
+		result := table.Resample(timetable.Month, nil, timetable.Last[Value])
+		assert.Equal(t, []time.Time{date("2022-01-31"), date("2022-02-28"), date("2022-04-01")}, result.Times())
+		assert.Equal(t, [][]Value{{2, 4, 5}, {20, 40, 50}}, result.Values())
+	})
+
+	t.Run("quarter sum", func(t *testing.T) {
+		result := table.Resample(timetable.Quarter, nil, timetable.Sum[Value])
+		assert.Equal(t, []time.Time{date("2022-02-28"), date("2022-04-01")}, result.Times())
+		assert.Equal(t, [][]Value{{10, 5}, {100, 50}}, result.Values())
+	})
+
+	t.Run("time zone", func(t *testing.T) {
+		newYork, err := time.LoadLocation("America/New_York")
+		if err != nil {
+			t.Skip(err)
+		}
+		// Midnight UTC on February 1st is still January 31st in New York.
+		result := table.Resample(timetable.Month, newYork, timetable.First[Value])
+		assert.Equal(t, []time.Time{date("2022-02-01"), date("2022-02-28"), date("2022-04-01")}, result.Times())
+		assert.Equal(t, [][]Value{{1, 4, 5}, {10, 40, 50}}, result.Values())
+	})
+
+	t.Run("empty table", func(t *testing.T) {
+		result := timetable.New[Value]().Resample(timetable.Year, nil, timetable.Last[Value])
+		assert.Equal(t, 0, result.NumberOfRows())
+	})
+}
+
+func TestList_Resample(t *testing.T) {
+	list := List{elV("2022-10-21", 2), elV("2022-10-17", 1), elV("2022-10-24", 3)}
+	result := list.Resample(timetable.Week, nil, timetable.Max[Value])
+	assert.Equal(t, List{elV("2022-10-21", 2), elV("2022-10-24", 3)}, result)
+}
@@ -0,0 +1,124 @@
+package timetable
+
+import (
+	"math"
+	"slices"
+	"time"
+)
+
+// FirstRows says what happens to the leading rows of a return calculation
+// that have no earlier row to compare with.
+type FirstRows int
+
+const (
+	// DropFirstRows removes the leading rows from the result.
+	DropFirstRows FirstRows = iota
+
+	// MarkFirstRowsMissing keeps the leading rows, sets their values to NaN and marks them missing.
+	MarkFirstRowsMissing
+)
+
+// PctChange returns the percent change of each value from the value periods rows earlier:
+// v[i] / v[i-periods] - 1. The first periods rows are handled according to first.
+// A change from or to a missing cell is NaN and marked missing.
+func PctChange[Value Float](table *Compact[Value], periods int, first FirstRows) *Compact[Value] {
+	return mapPriorValues(table, periods, first, func(previous, current Value) Value {
+		return current/previous - 1
+	})
+}
+
+// LogReturns returns the natural log of the ratio of each value to the one before it: ln(v[i] / v[i-1]).
+// The first row is handled according to first.
+func LogReturns[Value Float](table *Compact[Value], first FirstRows) *Compact[Value] {
+	return mapPriorValues(table, 1, first, func(previous, current Value) Value {
+		return Value(math.Log(float64(current / previous)))
+	})
+}
+
+// CumulativeReturn returns the growth of 1 invested at the start of a table of returns.
+// Each row holds the growth including that row's return. NaN and missing returns leave the growth unchanged.
+func CumulativeReturn[Value Float](returns *Compact[Value]) *Compact[Value] {
+	values := make([][]Value, len(returns.values))
+	for column := range returns.values {
+		values[column] = growth(returns.values[column], returns.missingColumn(column), 1)
+	}
+	return &Compact[Value]{times: slices.Clip(returns.times), values: values, columns: slices.Clone(returns.columns)}
+}
+
+// PriceIndex rebuilds a price index from a table of returns. It is the inverse of PctChange
+// with one period and DropFirstRows: the result starts with a row at start holding base
+// followed by one row per row of returns. The start time should be before the first row of returns.
+// NaN and missing returns leave the index unchanged.
+func PriceIndex[Value Float](returns *Compact[Value], start time.Time, base Value) *Compact[Value] {
+	times := make([]time.Time, 0, len(returns.times)+1)
+	times = append(append(times, start), returns.times...)
+	values := make([][]Value, len(returns.values))
+	for column := range returns.values {
+		values[column] = append([]Value{base}, growth(returns.values[column], returns.missingColumn(column), base)...)
+	}
+	return &Compact[Value]{times: times, values: values, columns: slices.Clone(returns.columns)}
+}
+
+// ListPctChange is like PctChange.
+func ListPctChange[Value Float](list List[Value], periods int, first FirstRows) List[Value] {
+	return listColumn(PctChange(New(list), periods, first))
+}
+
+// ListLogReturns is like LogReturns.
+func ListLogReturns[Value Float](list List[Value], first FirstRows) List[Value] {
+	return listColumn(LogReturns(New(list), first))
+}
+
+// ListCumulativeReturn is like CumulativeReturn.
+func ListCumulativeReturn[Value Float](returns List[Value]) List[Value] {
+	return listColumn(CumulativeReturn(New(returns)))
+}
+
+// ListPriceIndex is like PriceIndex.
+func ListPriceIndex[Value Float](returns List[Value], start time.Time, base Value) List[Value] {
+	return listColumn(PriceIndex(New(returns), start, base))
+}
+
+func listColumn[Value any](table *Compact[Value]) List[Value] {
+	list, ok := table.Column(0)
+	if !ok {
+		return List[Value]{}
+	}
+	return list
+}
+
+func mapPriorValues[Value Float](table *Compact[Value], periods int, first FirstRows, fn func(previous, current Value) Value) *Compact[Value] {
+	periods = max(periods, 1)
+	skip := 0
+	if first == DropFirstRows {
+		skip = min(periods, len(table.times))
+	}
+	values := make([][]Value, len(table.values))
+	masks := make([][]bool, len(table.values))
+	for column, input := range table.values {
+		result := make([]Value, len(input))
+		missing := make([]bool, len(input))
+		for row := range input {
+			if row < periods || table.IsMissing(row-periods, column) || table.IsMissing(row, column) {
+				result[row] = Value(math.NaN())
+				missing[row] = true
+				continue
+			}
+			result[row] = fn(input[row-periods], input[row])
+		}
+		values[column], masks[column] = result[skip:], missing[skip:]
+	}
+	return &Compact[Value]{times: slices.Clip(table.times[skip:]), values: values, columns: slices.Clone(table.columns), missing: compactMask(masks)}
+}
+
+func growth[Value Float](returns []Value, missing []bool, base Value) []Value {
+	result := make([]Value, len(returns))
+	level := base
+	for row, r := range returns {
+		if !math.IsNaN(float64(r)) && (missing == nil || !missing[row]) {
+			level *= 1 + r
+		}
+		result[row] = level
+	}
+	return result
+}
@@ -0,0 +1,103 @@
+package timetable_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/portfoliotree/timetable"
+)
+
+type FloatList = timetable.List[float64]
+
+func elF(t string, v float64) timetable.Cell[float64] {
+	return timetable.NewCell(date(t), v)
+}
+
+func prices() *timetable.Compact[float64] {
+	return timetable.New(
+		FloatList{elF(day0, 100), elF(day1, 110), elF(day2, 99), elF(day3, 108.9)},
+		FloatList{elF(day0, 10), elF(day1, 10), elF(day2, 20), elF(day3, 10)},
+	)
+}
+
+func TestPctChange(t *testing.T) {
+	t.Run("drop first rows", func(t *testing.T) {
+		result := timetable.PctChange(prices(), 1, timetable.DropFirstRows)
+		assert.Equal(t, []time.Time{date(day1), date(day2), date(day3)}, result.Times())
+		values := result.Values()
+		assert.InDeltaSlice(t, []float64{0.1, -0.1, 0.1}, values[0], 1e-9)
+		assert.InDeltaSlice(t, []float64{0, 1, -0.5}, values[1], 1e-9)
+	})
+
+	t.Run("mark first rows missing", func(t *testing.T) {
+		result := timetable.PctChange(prices(), 2, timetable.MarkFirstRowsMissing)
+		assert.Equal(t, 4, result.NumberOfRows())
+		values := result.Values()
+		assert.True(t, math.IsNaN(values[0][0]))
+		assert.True(t, math.IsNaN(values[0][1]))
+		assert.InDelta(t, -0.01, values[0][2], 1e-9)
+		assert.InDelta(t, -0.01, values[0][3], 1e-9)
+	})
+
+	t.Run("more periods than rows", func(t *testing.T) {
+		result := timetable.PctChange(prices(), 5, timetable.DropFirstRows)
+		assert.Equal(t, 0, result.NumberOfRows())
+		assert.Equal(t, 2, result.NumberOfColumns())
+	})
+}
+
+func TestLogReturns(t *testing.T) {
+	result := timetable.LogReturns(prices(), timetable.DropFirstRows)
+	values := result.Values()
+	assert.InDeltaSlice(t, []float64{0, math.Ln2, -math.Ln2}, values[1], 1e-9)
+}
+
+func TestCumulativeReturn(t *testing.T) {
+	returns := timetable.PctChange(prices(), 1, timetable.DropFirstRows)
+	growth := timetable.CumulativeReturn(returns)
+	assert.Equal(t, returns.Times(), growth.Times())
+	assert.InDeltaSlice(t, []float64{1.1, 0.99, 1.089}, growth.Values()[0], 1e-9)
+
+	t.Run("price index is the inverse of pct change", func(t *testing.T) {
+		index := timetable.PriceIndex(returns, date(day0), 100)
+		assert.Equal(t, prices().Times(), index.Times())
+		assert.InDeltaSlice(t, prices().Values()[0], index.Values()[0], 1e-9)
+		assert.InDeltaSlice(t, []float64{100, 100, 200, 100}, index.Values()[1], 1e-9)
+	})
+
+	t.Run("missing returns", func(t *testing.T) {
+		returns := timetable.New(FloatList{elF(day0, math.NaN()), elF(day1, 0.5)})
+		assert.Equal(t, [][]float64{{1, 1.5}}, timetable.CumulativeReturn(returns).Values())
+	})
+}
+
+func TestListReturns(t *testing.T) {
+	list := FloatList{elF(day1, 110), elF(day0, 100)}
+	returns := timetable.ListPctChange(list, 1, timetable.DropFirstRows)
+	if assert.Len(t, returns, 1) {
+		assert.Equal(t, date(day1), returns[0].Time())
+		assert.InDelta(t, 0.1, returns[0].Value(), 1e-9)
+	}
+
+	logReturns := timetable.ListLogReturns(list, timetable.MarkFirstRowsMissing)
+	if assert.Len(t, logReturns, 2) {
+		assert.True(t, math.IsNaN(logReturns[0].Value()))
+		assert.InDelta(t, math.Log(1.1), logReturns[1].Value(), 1e-9)
+	}
+
+	growth := timetable.ListCumulativeReturn(returns)
+	if assert.Len(t, growth, 1) {
+		assert.InDelta(t, 1.1, growth[0].Value(), 1e-9)
+	}
+
+	index := timetable.ListPriceIndex(returns, date(day0), 100)
+	if assert.Len(t, index, 2) {
+		assert.Equal(t, elF(day0, 100), index[0])
+		assert.InDelta(t, 110, index[1].Value(), 1e-9)
+	}
+
+	assert.Len(t, timetable.ListPctChange(FloatList{}, 1, timetable.DropFirstRows), 0)
+}
@@ -0,0 +1,274 @@
+package timetable
+
+import (
+	"cmp"
+	"math"
+	"slices"
+	"time"
+)
+
+// Window selects the rows a rolling computation combines for each row.
+// The zero Window is an expanding window that holds every row up to the current one.
+type Window struct {
+	// Rows is the number of rows ending at the current row.
+	Rows int
+
+	// Duration selects the rows whose time is after t - Duration, up to and including the current row at t.
+	// It is used when Rows is zero.
+	Duration time.Duration
+
+	// MinObservations is the number of cells that are not missing a window needs before it produces a value.
+	// When it is zero, a row window needs to be full and other windows need one row.
+	MinObservations int
+}
+
+// RowWindow returns a window of the n rows ending at the current row.
+func RowWindow(n int) Window { return Window{Rows: n} }
+
+// DurationWindow returns a window of the rows less than d before the current row.
+func DurationWindow(d time.Duration) Window { return Window{Duration: d} }
+
+func (window Window) excludes(times []time.Time, start, end int) bool {
+	switch {
+	case window.Rows > 0:
+		return end-start+1 > window.Rows
+	case window.Duration > 0:
+		return !times[start].After(times[end].Add(-window.Duration))
+	default:
+		return false
+	}
+}
+
+func (window Window) minObservations() int {
+	switch {
+	case window.MinObservations > 0:
+		return window.MinObservations
+	case window.Rows > 0:
+		return window.Rows
+	default:
+		return 1
+	}
+}
+
+// WindowReducer keeps a running result as a window slides along a column.
+// Values are removed in the order they were added, so Remove is always called with the oldest value in the window.
+type WindowReducer[Value any] interface {
+	Add(value Value)
+	Remove(value Value)
+	Result() Value
+}
+
+// Rolling slides window along each column and stores the result of a new reducer from newReducer
+// at the last row of each window. Missing cells are not given to the reducer and do not count as observations.
+// Rows where the window has fewer than its minimum observations are marked missing.
+// Each column is computed in one pass.
+func (table *Compact[Value]) Rolling(window Window, newReducer func() WindowReducer[Value]) *Compact[Value] {
+	values := make([][]Value, len(table.values))
+	masks := make([][]bool, len(table.values))
+	for column := range table.values {
+		values[column], masks[column] = rollColumn(table.times, table.values[column], table.missingColumn(column), window, newReducer())
+	}
+	return &Compact[Value]{times: slices.Clip(table.times), values: values, columns: slices.Clone(table.columns), missing: compactMask(masks)}
+}
+
+// Rolling is like Compact.Rolling. The list is sorted in place first.
+func (list List[Value]) Rolling(window Window, newReducer func() WindowReducer[Value]) List[Value] {
+	slices.SortFunc(list, Cell[Value].compareTimes)
+	times := make([]time.Time, len(list))
+	values := make([]Value, len(list))
+	missing := make([]bool, len(list))
+	for i, cell := range list {
+		times[i], values[i], missing[i] = cell.time, cell.value, cell.missing
+	}
+	values, missing = rollColumn(times, values, missing, window, newReducer())
+	result := make(List[Value], len(list))
+	for i := range result {
+		result[i] = Cell[Value]{time: times[i], value: values[i], missing: missing[i]}
+	}
+	return result
+}
+
+// rollColumn returns the rolling results for a column and which of them are missing.
+// A nil missing slice means no value is missing.
+func rollColumn[Value any](times []time.Time, values []Value, missing []bool, window Window, reducer WindowReducer[Value]) ([]Value, []bool) {
+	result := make([]Value, len(values))
+	resultMissing := make([]bool, len(values))
+	isMissing := func(row int) bool { return missing != nil && missing[row] }
+	minObservations := window.minObservations()
+	start, observations := 0, 0
+	for end, value := range values {
+		if !isMissing(end) {
+			reducer.Add(value)
+			observations++
+		}
+		for start < end && window.excludes(times, start, end) {
+			if !isMissing(start) {
+				reducer.Remove(values[start])
+				observations--
+			}
+			start++
+		}
+		if observations >= minObservations {
+			result[end] = reducer.Result()
+		} else {
+			resultMissing[end] = true
+		}
+	}
+	return result, resultMissing
+}
+
+// RollingSum returns a WindowReducer for the sum of a window.
+func RollingSum[Value Number]() WindowReducer[Value] { return new(rollingSum[Value]) }
+
+type rollingSum[Value Number] struct{ sum Value }
+
+func (r *rollingSum[Value]) Add(value Value)    { r.sum += value }
+func (r *rollingSum[Value]) Remove(value Value) { r.sum -= value }
+func (r *rollingSum[Value]) Result() Value      { return r.sum }
+
+// RollingMean returns a WindowReducer for the arithmetic mean of a window. Integer means are truncated.
+// The sum is kept in a 64-bit type, so small integer types do not overflow.
+func RollingMean[Value Number]() WindowReducer[Value] {
+	return &rollingMean[Value]{sum: newWideSum[Value]()}
+}
+
+type rollingMean[Value Number] struct {
+	sum   wideSum[Value]
+	count int
+}
+
+func (r *rollingMean[Value]) Add(value Value)    { r.sum.add(value); r.count++ }
+func (r *rollingMean[Value]) Remove(value Value) { r.sum.subtract(value); r.count-- }
+func (r *rollingMean[Value]) Result() Value {
+	if r.count == 0 {
+		return 0
+	}
+	return r.sum.mean(r.count)
+}
+
+// RollingStd returns a WindowReducer for the sample standard deviation of a window.
+// Windows with fewer than two values have a standard deviation of zero.
+// It updates a running mean and sum of squared deviations as values enter and leave the window. When removals cancel
+// out most of the variance seen since the last recompute, it recomputes them from the values in the window,
+// so rounding errors do not build up as the window slides.
+func RollingStd[Value Number]() WindowReducer[Value] { return new(rollingStd[Value]) }
+
+// rollingCancellation is how far the sum of squared deviations may fall below its peak before it is recomputed.
+const rollingCancellation = 1e-6
+
+type rollingStd[Value Number] struct {
+	// window holds the values less reference, which is the first value added since the window was last empty.
+	window    []float64
+	reference float64
+
+	mean, sumOfSquares, peak float64
+}
+
+func (r *rollingStd[Value]) Add(value Value) {
+	if len(r.window) == 0 {
+		r.reference, r.mean, r.sumOfSquares, r.peak = float64(value), 0, 0, 0
+	}
+	x := float64(value) - r.reference
+	r.window = append(r.window, x)
+	d := x - r.mean
+	r.mean += d / float64(len(r.window))
+	r.sumOfSquares += d * (x - r.mean)
+	r.peak = max(r.peak, r.sumOfSquares)
+}
+
+func (r *rollingStd[Value]) Remove(Value) {
+	x := r.window[0]
+	r.window = r.window[1:]
+	if len(r.window) == 0 {
+		return
+	}
+	d := x - r.mean
+	r.mean -= d / float64(len(r.window))
+	r.sumOfSquares -= d * (x - r.mean)
+	if r.sumOfSquares < r.peak*rollingCancellation {
+		r.recompute()
+	}
+}
+
+func (r *rollingStd[Value]) recompute() {
+	var sum float64
+	for _, x := range r.window {
+		sum += x
+	}
+	r.mean = sum / float64(len(r.window))
+	r.sumOfSquares = 0
+	for _, x := range r.window {
+		r.sumOfSquares += (x - r.mean) * (x - r.mean)
+	}
+	r.peak = r.sumOfSquares
+}
+
+func (r *rollingStd[Value]) Result() Value {
+	n := len(r.window)
+	if n < 2 {
+		return 0
+	}
+	return fromFloat[Value](math.Sqrt(max(r.sumOfSquares, 0) / float64(n-1)))
+}
+
+// RollingMin returns a WindowReducer for the smallest value in a window.
+func RollingMin[Value cmp.Ordered]() WindowReducer[Value] {
+	return &rollingExtremum[Value]{keep: func(a, b Value) bool { return a < b }}
+}
+
+// RollingMax returns a WindowReducer for the largest value in a window.
+func RollingMax[Value cmp.Ordered]() WindowReducer[Value] {
+	return &rollingExtremum[Value]{keep: func(a, b Value) bool { return a > b }}
+}
+
+// rollingExtremum keeps a monotonic queue of the values that may still become the extremum of the window.
+type rollingExtremum[Value cmp.Ordered] struct {
+	queue          []indexedValue[Value]
+	added, removed int
+	keep           func(a, b Value) bool
+}
+
+type indexedValue[Value any] struct {
+	index int
+	value Value
+}
+
+func (r *rollingExtremum[Value]) Add(value Value) {
+	for len(r.queue) > 0 && !r.keep(r.queue[len(r.queue)-1].value, value) {
+		r.queue = r.queue[:len(r.queue)-1]
+	}
+	r.queue = append(r.queue, indexedValue[Value]{index: r.added, value: value})
+	r.added++
+}
+
+func (r *rollingExtremum[Value]) Remove(Value) {
+	if len(r.queue) > 0 && r.queue[0].index == r.removed {
+		r.queue = r.queue[1:]
+	}
+	r.removed++
+}
+
+func (r *rollingExtremum[Value]) Result() Value {
+	if len(r.queue) == 0 {
+		var zero Value
+		return zero
+	}
+	return r.queue[0].value
+}
+
+// RollingAggregation returns a function that makes WindowReducers calling aggregate with the values in the window.
+// Unlike the other reducers it does work proportional to the window size for each row.
+func RollingAggregation[Value any](aggregate Aggregation[Value]) func() WindowReducer[Value] {
+	return func() WindowReducer[Value] {
+		return &rollingAggregation[Value]{aggregate: aggregate}
+	}
+}
+
+type rollingAggregation[Value any] struct {
+	window    []Value
+	aggregate Aggregation[Value]
+}
+
+func (r *rollingAggregation[Value]) Add(value Value) { r.window = append(r.window, value) }
+func (r *rollingAggregation[Value]) Remove(Value)    { r.window = r.window[1:] }
+func (r *rollingAggregation[Value]) Result() Value   { return r.aggregate(r.window) }
@@ -0,0 +1,139 @@
+package timetable_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/portfoliotree/timetable"
+)
+
+func TestCompact_Rolling(t *testing.T) {
+	table := timetable.New(
+		List{elV(day0, 4), elV(day1, 2), elV(day2, 6), elV(day3, 1), elV(dayAfter, 3)},
+		List{elV(day0, 1), elV(day1, 1), elV(day2, 1), elV(day3, 1), elV(dayAfter, 1)},
+	)
+
+	for _, tt := range []struct {
+		Name    string
+		Window  timetable.Window
+		Reducer func() timetable.WindowReducer[Value]
+		Values  [][]Value
+	}{
+		{
+			Name:    "sum of three rows",
+			Window:  timetable.RowWindow(3),
+			Reducer: timetable.RollingSum[Value],
+			Values:  [][]Value{{0, 0, 12, 9, 10}, {0, 0, 3, 3, 3}},
+		},
+		{
+			Name:    "mean of two rows",
+			Window:  timetable.RowWindow(2),
+			Reducer: timetable.RollingMean[Value],
+			Values:  [][]Value{{0, 3, 4, 3, 2}, {0, 1, 1, 1, 1}},
+		},
+		{
+			Name:    "min of three rows with a minimum of one observation",
+			Window:  timetable.Window{Rows: 3, MinObservations: 1},
+			Reducer: timetable.RollingMin[Value],
+			Values:  [][]Value{{4, 2, 2, 1, 1}, {1, 1, 1, 1, 1}},
+		},
+		{
+			Name:    "max of two rows",
+			Window:  timetable.RowWindow(2),
+			Reducer: timetable.RollingMax[Value],
+			Values:  [][]Value{{0, 4, 6, 6, 3}, {0, 1, 1, 1, 1}},
+		},
+		{
+			Name:    "expanding sum",
+			Window:  timetable.Window{},
+			Reducer: timetable.RollingSum[Value],
+			Values:  [][]Value{{4, 6, 12, 13, 16}, {1, 2, 3, 4, 5}},
+		},
+		{
+			// day1 is a Friday so the three day window on Monday only holds Monday.
+			Name:    "sum over a duration",
+			Window:  timetable.DurationWindow(72 * time.Hour),
+			Reducer: timetable.RollingSum[Value],
+			Values:  [][]Value{{4, 6, 6, 7, 10}, {1, 2, 1, 2, 3}},
+		},
+		{
+			Name:    "custom aggregation",
+			Window:  timetable.RowWindow(2),
+			Reducer: timetable.RollingAggregation(timetable.First[Value]),
+			Values:  [][]Value{{0, 4, 2, 6, 1}, {0, 1, 1, 1, 1}},
+		},
+	} {
+		t.Run(tt.Name, func(t *testing.T) {
+			result := table.Rolling(tt.Window, tt.Reducer)
+			assert.Equal(t, table.Times(), result.Times())
+			assert.Equal(t, tt.Values, result.Values())
+		})
+	}
+}
+
+func TestRollingMean_smallIntegers(t *testing.T) {
+	start := date(day0)
+	list := make(timetable.List[uint8], 400)
+	for i := range list {
+		list[i] = timetable.NewCell(start.AddDate(0, 0, i), uint8(200+i%3))
+	}
+	result := list.Rolling(timetable.RowWindow(300), timetable.RollingMean[uint8])
+	assert.True(t, result[298].IsMissing())
+	assert.Equal(t, uint8(201), result[299].Value())
+	assert.Equal(t, uint8(201), result[399].Value())
+}
+
+func TestRollingStd(t *testing.T) {
+	list := timetable.List[float64]{
+		timetable.NewCell(date(day0), 2.0),
+		timetable.NewCell(date(day1), 4.0),
+		timetable.NewCell(date(day2), 4.0),
+		timetable.NewCell(date(day3), 5.0),
+	}
+	result := list.Rolling(timetable.RowWindow(3), timetable.RollingStd[float64])
+	if assert.Len(t, result, 4) {
+		assert.Zero(t, result[1].Value())
+		assert.InDelta(t, 1.1547005, result[2].Value(), 1e-6)
+		assert.InDelta(t, 0.5773503, result[3].Value(), 1e-6)
+		assert.Equal(t, date(day3), result[3].Time())
+	}
+
+	t.Run("high nearly constant level", func(t *testing.T) {
+		start := date(day0)
+		var list timetable.List[float64]
+		for i := range 200 {
+			list = append(list, timetable.NewCell(start.AddDate(0, 0, i), 1e6+float64(i%7)*1000.37))
+		}
+		for i := 200; i < 220; i++ {
+			list = append(list, timetable.NewCell(start.AddDate(0, 0, i), 1e6+0.37))
+		}
+		list = append(list, timetable.NewCell(start.AddDate(0, 0, 220), 1e6+0.38))
+		result := list.Rolling(timetable.RowWindow(20), timetable.RollingStd[float64])
+		assert.Zero(t, result[219].Value())
+		assert.InDelta(t, 0.01/math.Sqrt(20), result[220].Value(), 1e-9)
+	})
+
+	t.Run("matches the standard deviation of each window", func(t *testing.T) {
+		start := date(day0)
+		var (
+			list   timetable.List[float64]
+			values []float64
+		)
+		for i := range 300 {
+			value := 101.37
+			if i < 260 {
+				value *= 1 + 0.05*math.Sin(float64(i*i))
+			}
+			values = append(values, value)
+			list = append(list, timetable.NewCell(start.AddDate(0, 0, i), value))
+		}
+		result := list.Rolling(timetable.RowWindow(20), timetable.RollingStd[float64])
+		for i := 19; i < len(values); i++ {
+			assert.InDelta(t, timetable.Std(values[i-19:i+1]), result[i].Value(), 1e-9, "row %d", i)
+		}
+		assert.Zero(t, result[299].Value())
+	})
+}
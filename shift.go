@@ -0,0 +1,97 @@
+package timetable
+
+import (
+	"fmt"
+	"slices"
+	"time"
+)
+
+// Shift moves the values of every column down by rows, keeping the times of the table.
+// A positive rows lags the values so each row holds the value from rows earlier;
+// a negative rows leads them so each row holds the value from later rows.
+// Cells with no value to move into them are marked missing.
+func (table *Compact[Value]) Shift(rows int) *Compact[Value] {
+	values := make([][]Value, len(table.values))
+	masks := make([][]bool, len(table.values))
+	for column := range table.values {
+		values[column], masks[column] = table.shiftColumn(column, rows)
+	}
+	return &Compact[Value]{times: slices.Clip(table.times), values: values, columns: slices.Clone(table.columns), missing: compactMask(masks)}
+}
+
+// ShiftColumn is like Shift but only moves the values of one column. The other columns are copied unchanged.
+// It returns an error if the column does not exist.
+func (table *Compact[Value]) ShiftColumn(column, rows int) (*Compact[Value], error) {
+	if column < 0 || column >= len(table.values) {
+		return nil, fmt.Errorf("column %d out of range for table with %d columns", column, len(table.values))
+	}
+	values := table.Values()
+	masks := make([][]bool, len(table.values))
+	for i := range masks {
+		masks[i] = slices.Clone(table.missingColumn(i))
+	}
+	values[column], masks[column] = table.shiftColumn(column, rows)
+	return &Compact[Value]{times: slices.Clip(table.times), values: values, columns: slices.Clone(table.columns), missing: compactMask(masks)}, nil
+}
+
+func (table *Compact[Value]) shiftColumn(column, rows int) ([]Value, []bool) {
+	values := make([]Value, len(table.times))
+	missing := make([]bool, len(table.times))
+	for row := range values {
+		source := row - rows
+		if source < 0 || source >= len(values) {
+			missing[row] = true
+			continue
+		}
+		values[row] = table.values[column][source]
+		missing[row] = table.IsMissing(source, column)
+	}
+	return values, missing
+}
+
+// ShiftByDuration moves every cell to the time d after its own and realigns the cells with the times of the table.
+// The times kept follow join, as if each shifted column were added to a table holding only the times of this one.
+// Cells left without a value are marked missing and then replaced using fill; fill may be nil.
+func (table *Compact[Value]) ShiftByDuration(d time.Duration, fill Filler[Value], join JoinMode) *Compact[Value] {
+	lists := make([]List[Value], len(table.values))
+	for column := range lists {
+		lists[column], _ = table.Column(column)
+		for i := range lists[column] {
+			lists[column][i].time = lists[column][i].time.Add(d)
+		}
+	}
+	index := &Compact[Value]{times: table.times}
+	if table.isEmpty() {
+		index.times = []time.Time{}
+	}
+	shifted := index.addColumns(lists, table.columnInfos(), nil, join)
+	if fill != nil && shifted.HasMissing() {
+		shifted = shifted.FillMissing(fill)
+	}
+	return shifted
+}
+
+// Shift is like Compact.Shift. The list is sorted in place first.
+func (list List[Value]) Shift(rows int) List[Value] {
+	slices.SortFunc(list, Cell[Value].compareTimes)
+	result := make(List[Value], len(list))
+	for i, cell := range list {
+		source := i - rows
+		if source < 0 || source >= len(list) {
+			result[i] = NewMissingCell[Value](cell.time)
+			continue
+		}
+		result[i] = Cell[Value]{time: cell.time, value: list[source].value, missing: list[source].missing}
+	}
+	return result
+}
+
+// ShiftByDuration returns a copy of the list with every cell moved to the time d after its own.
+// Add the result to a table with a join to realign it with other times.
+func (list List[Value]) ShiftByDuration(d time.Duration) List[Value] {
+	result := slices.Clone(list)
+	for i := range result {
+		result[i].time = result[i].time.Add(d)
+	}
+	return result
+}
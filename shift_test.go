@@ -0,0 +1,101 @@
+package timetable_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/portfoliotree/timetable"
+)
+
+func TestCompact_Shift(t *testing.T) {
+	table, err := timetable.NewNamed([]string{"a", "b"},
+		List{elV(day0, 1), elV(day1, 2), elV(day2, 3)},
+		List{elV(day0, 10), elV(day1, 20), elV(day2, 30)},
+	)
+	require.NoError(t, err)
+
+	for _, tt := range []struct {
+		Name    string
+		Rows    int
+		Values  [][]Value
+		Missing [][]bool
+	}{
+		{Name: "lag", Rows: 1, Values: [][]Value{{0, 1, 2}, {0, 10, 20}}, Missing: [][]bool{{true, false, false}, {true, false, false}}},
+		{Name: "lead", Rows: -2, Values: [][]Value{{3, 0, 0}, {30, 0, 0}}, Missing: [][]bool{{false, true, true}, {false, true, true}}},
+		{Name: "zero", Rows: 0, Values: [][]Value{{1, 2, 3}, {10, 20, 30}}, Missing: [][]bool{{false, false, false}, {false, false, false}}},
+		{Name: "past the end", Rows: 5, Values: [][]Value{{0, 0, 0}, {0, 0, 0}}, Missing: [][]bool{{true, true, true}, {true, true, true}}},
+	} {
+		t.Run(tt.Name, func(t *testing.T) {
+			result := table.Shift(tt.Rows)
+			assert.Equal(t, table.Times(), result.Times())
+			assert.Equal(t, tt.Values, result.Values())
+			assert.Equal(t, tt.Missing, missingCells(result))
+			assert.Equal(t, []string{"a", "b"}, result.ColumnNames())
+		})
+	}
+
+	t.Run("missing cells move with their values", func(t *testing.T) {
+		assert.Equal(t, [][]bool{{true, false, false, false}, {true, false, true, true}}, missingCells(maskedTable().Shift(1)))
+	})
+}
+
+func TestCompact_ShiftColumn(t *testing.T) {
+	table := timetable.New(
+		List{elV(day0, 1), elV(day1, 2), elV(day2, 3)},
+		List{elV(day0, 10), elV(day1, 20), elV(day2, 30)},
+	)
+	result, err := table.ShiftColumn(1, -1)
+	require.NoError(t, err)
+	assert.Equal(t, [][]Value{{1, 2, 3}, {20, 30, 0}}, result.Values())
+	assert.Equal(t, [][]bool{{false, false, false}, {false, false, true}}, missingCells(result))
+
+	_, err = table.ShiftColumn(2, 1)
+	assert.Error(t, err)
+}
+
+func TestCompact_ShiftByDuration(t *testing.T) {
+	// day0 and day1 are Thursday and Friday; day2 is the following Monday.
+	table := timetable.New(List{elV(day0, 1), elV(day1, 2), elV(day2, 3)})
+	day := 24 * time.Hour
+
+	t.Run("left join", func(t *testing.T) {
+		result := table.ShiftByDuration(day, nil, timetable.JoinLeft)
+		assert.Equal(t, table.Times(), result.Times())
+		assert.Equal(t, [][]Value{{0, 1, 0}}, result.Values())
+		assert.Equal(t, [][]bool{{true, false, true}}, missingCells(result))
+	})
+
+	t.Run("left join with fill", func(t *testing.T) {
+		result := table.ShiftByDuration(day, timetable.ForwardFill[Value](0), timetable.JoinLeft)
+		assert.Equal(t, [][]Value{{0, 1, 1}}, result.Values())
+		assert.Equal(t, [][]bool{{true, false, false}}, missingCells(result))
+	})
+
+	t.Run("outer join", func(t *testing.T) {
+		result := table.ShiftByDuration(day, nil, timetable.JoinOuter)
+		assert.Equal(t, []time.Time{date(day0), date(day1), date(day1).Add(day), date(day2), date(day2).Add(day)}, result.Times())
+		assert.Equal(t, [][]Value{{0, 1, 2, 0, 3}}, result.Values())
+	})
+
+	t.Run("names", func(t *testing.T) {
+		named, err := timetable.NewNamed([]string{"a"}, List{elV(day0, 1)})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"a"}, named.ShiftByDuration(day, nil, timetable.JoinOuter).ColumnNames())
+	})
+}
+
+func TestList_Shift(t *testing.T) {
+	list := List{elV(day1, 2), elV(day0, 1), elV(day2, 3)}
+	assert.Equal(t, List{timetable.NewMissingCell[Value](date(day0)), elV(day1, 1), elV(day2, 2)}, list.Shift(1))
+	assert.Equal(t, List{elV(day0, 2), elV(day1, 3), timetable.NewMissingCell[Value](date(day2))}, list.Shift(-1))
+}
+
+func TestList_ShiftByDuration(t *testing.T) {
+	list := List{elV(day0, 1), elV(day1, 2)}
+	result := list.ShiftByDuration(72 * time.Hour)
+	assert.Equal(t, List{timetable.NewCell(date(day0).Add(72*time.Hour), 1), timetable.NewCell(date(day1).Add(72*time.Hour), 2)}, result)
+	assert.Equal(t, List{elV(day0, 1), elV(day1, 2)}, list, "it does not modify the list")
+}
@@ -0,0 +1,144 @@
+package stats
+
+import (
+	"math"
+	"slices"
+	"time"
+
+	"github.com/portfoliotree/timetable"
+)
+
+// Kind says whether a series holds prices or periodic returns.
+type Kind int
+
+const (
+	// Returns are simple periodic returns. The growth of the returns starts at 1 before the first cell.
+	Returns Kind = iota
+
+	// Prices are levels such as closing prices or a net asset value.
+	Prices
+)
+
+// Episode is one drawdown: a fall from a peak, the lowest point reached and, if it happened, the recovery to the peak.
+type Episode struct {
+	// Peak is the time of the high the drawdown fell from.
+	// For returns that fall from the start, there is no cell at the peak and Peak is the zero time.
+	Peak time.Time
+
+	// Trough is the time of the lowest point of the drawdown.
+	Trough time.Time
+
+	// Recovery is the time the series first got back to the peak. It is the zero time when Recovered is false.
+	Recovery time.Time
+
+	// Recovered is false for a drawdown that is still open at the end of the series.
+	Recovered bool
+
+	// Depth is the fall from the peak to the trough as a negative fraction.
+	Depth float64
+}
+
+// level is the value of a series at a time, as a price or the growth of the returns so far.
+type level struct {
+	time  time.Time
+	value float64
+}
+
+// levels returns the levels of the usable cells of a list in time order.
+func levels(list timetable.List[float64], kind Kind) []level {
+	cells := slices.Clone(list)
+	slices.SortStableFunc(cells, func(a, b timetable.Cell[float64]) int { return a.Time().Compare(b.Time()) })
+	result := make([]level, 0, len(cells))
+	growth := 1.0
+	for _, cell := range cells {
+		if cell.IsMissing() || math.IsNaN(cell.Value()) {
+			continue
+		}
+		value := cell.Value()
+		if kind == Returns {
+			growth *= 1 + value
+			value = growth
+		}
+		result = append(result, level{time: cell.Time(), value: value})
+	}
+	return result
+}
+
+// Underwater returns the drawdown at each cell: how far the series is below its highest earlier level, as a negative fraction or zero.
+// Missing and NaN cells give missing cells.
+func Underwater(list timetable.List[float64], kind Kind) timetable.List[float64] {
+	cells := slices.Clone(list)
+	slices.SortStableFunc(cells, func(a, b timetable.Cell[float64]) int { return a.Time().Compare(b.Time()) })
+	result := make(timetable.List[float64], len(cells))
+	peak, growth := math.Inf(-1), 1.0
+	if kind == Returns {
+		peak = 1
+	}
+	for i, cell := range cells {
+		if cell.IsMissing() || math.IsNaN(cell.Value()) {
+			result[i] = timetable.NewMissingCell[float64](cell.Time())
+			continue
+		}
+		value := cell.Value()
+		if kind == Returns {
+			growth *= 1 + value
+			value = growth
+		}
+		peak = max(peak, value)
+		result[i] = timetable.NewCell(cell.Time(), value/peak-1)
+	}
+	return result
+}
+
+// DrawdownEpisodes returns every drawdown of the series in time order. The last episode may still be open.
+func DrawdownEpisodes(list timetable.List[float64], kind Kind) []Episode {
+	var (
+		episodes []Episode
+		current  *Episode
+		peak     = level{value: math.Inf(-1)}
+	)
+	if kind == Returns {
+		peak.value = 1
+	}
+	for _, l := range levels(list, kind) {
+		if l.value >= peak.value {
+			if current != nil {
+				current.Recovery, current.Recovered = l.time, true
+				episodes = append(episodes, *current)
+				current = nil
+			}
+			peak = l
+			continue
+		}
+		depth := l.value/peak.value - 1
+		if current == nil {
+			current = &Episode{Peak: peak.time, Trough: l.time, Depth: depth}
+		} else if depth < current.Depth {
+			current.Trough, current.Depth = l.time, depth
+		}
+	}
+	if current != nil {
+		episodes = append(episodes, *current)
+	}
+	return episodes
+}
+
+// UnderwaterColumns is like Underwater for each column of a table. The result keeps the times and column names of the table.
+func UnderwaterColumns(table *timetable.Compact[float64], kind Kind) *timetable.Compact[float64] {
+	builder := timetable.NewBuilder[float64](nil, timetable.JoinOuter)
+	for column, list := range table.Columns() {
+		info, _ := table.ColumnInfo(column)
+		// The names of a table are unique, so adding them can not fail.
+		_ = builder.AddWithInfo(info, Underwater(list, kind))
+	}
+	return builder.Build()
+}
+
+// DrawdownEpisodesColumns is like DrawdownEpisodes for each column of a table.
+func DrawdownEpisodesColumns(table *timetable.Compact[float64], kind Kind) [][]Episode {
+	episodes := make([][]Episode, 0, table.NumberOfColumns())
+	for _, list := range table.Columns() {
+		episodes = append(episodes, DrawdownEpisodes(list, kind))
+	}
+	return episodes
+}
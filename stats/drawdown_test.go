@@ -0,0 +1,153 @@
+package stats_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/portfoliotree/timetable"
+	"github.com/portfoliotree/timetable/stats"
+)
+
+func cellValues(list timetable.List[float64]) []float64 {
+	values := make([]float64, len(list))
+	for i, cell := range list {
+		values[i] = cell.Value()
+	}
+	return values
+}
+
+func TestUnderwater(t *testing.T) {
+	times := monthEnds(5)
+
+	t.Run("returns", func(t *testing.T) {
+		underwater := stats.Underwater(returnsList(times, 0.1, -0.2, 0.05, 0.2, -0.1), stats.Returns)
+		require.Len(t, underwater, 5)
+		assert.Equal(t, times[2], underwater[2].Time())
+		assert.InDeltaSlice(t, []float64{0, -0.2, -0.16, 0, -0.1}, cellValues(underwater), 1e-12)
+	})
+
+	t.Run("returns falling from the start", func(t *testing.T) {
+		underwater := stats.Underwater(returnsList(times, -0.1, 0.2), stats.Returns)
+		assert.InDeltaSlice(t, []float64{-0.1, 0}, cellValues(underwater), 1e-12)
+	})
+
+	t.Run("prices", func(t *testing.T) {
+		underwater := stats.Underwater(returnsList(times, 100, 120, 90, 130, 117), stats.Prices)
+		assert.InDeltaSlice(t, []float64{0, 0, -0.25, 0, -0.1}, cellValues(underwater), 1e-12)
+	})
+
+	t.Run("missing and NaN cells", func(t *testing.T) {
+		list := append(returnsList(times, 100, math.NaN(), 90), timetable.NewMissingCell[float64](times[3]))
+		underwater := stats.Underwater(list, stats.Prices)
+		require.Len(t, underwater, 4)
+		assert.False(t, underwater[0].IsMissing())
+		assert.True(t, underwater[1].IsMissing())
+		assert.InDelta(t, -0.1, underwater[2].Value(), 1e-12)
+		assert.True(t, underwater[3].IsMissing())
+	})
+
+	t.Run("unsorted", func(t *testing.T) {
+		list := returnsList(times, 100, 120, 90)
+		underwater := stats.Underwater(timetable.List[float64]{list[2], list[0], list[1]}, stats.Prices)
+		assert.Equal(t, times[2], underwater[2].Time())
+		assert.InDelta(t, -0.25, underwater[2].Value(), 1e-12)
+	})
+}
+
+func TestDrawdownEpisodes(t *testing.T) {
+	times := monthEnds(5)
+
+	for _, tt := range []struct {
+		Name     string
+		List     timetable.List[float64]
+		Kind     stats.Kind
+		Episodes []stats.Episode
+	}{
+		{
+			Name: "recovered and open",
+			List: returnsList(times, 0.1, -0.2, 0.05, 0.2, -0.1),
+			Kind: stats.Returns,
+			Episodes: []stats.Episode{
+				{Peak: times[0], Trough: times[1], Recovery: times[3], Recovered: true, Depth: -0.2},
+				{Peak: times[3], Trough: times[4], Depth: -0.1},
+			},
+		},
+		{
+			Name: "returns falling from the start",
+			List: returnsList(times, -0.1, 0.2),
+			Kind: stats.Returns,
+			Episodes: []stats.Episode{
+				{Trough: times[0], Recovery: times[1], Recovered: true, Depth: -0.1},
+			},
+		},
+		{
+			Name: "prices recovering to the peak",
+			List: returnsList(times, 100, 120, 90, 96, 120),
+			Kind: stats.Prices,
+			Episodes: []stats.Episode{
+				{Peak: times[1], Trough: times[2], Recovery: times[4], Recovered: true, Depth: -0.25},
+			},
+		},
+		{
+			Name: "no drawdown",
+			List: returnsList(times, 1, 2, 3),
+			Kind: stats.Prices,
+		},
+	} {
+		t.Run(tt.Name, func(t *testing.T) {
+			episodes := stats.DrawdownEpisodes(tt.List, tt.Kind)
+			require.Len(t, episodes, len(tt.Episodes))
+			for i, expected := range tt.Episodes {
+				assert.Equal(t, expected.Peak, episodes[i].Peak)
+				assert.Equal(t, expected.Trough, episodes[i].Trough)
+				assert.Equal(t, expected.Recovery, episodes[i].Recovery)
+				assert.Equal(t, expected.Recovered, episodes[i].Recovered)
+				assert.InDelta(t, expected.Depth, episodes[i].Depth, 1e-12)
+			}
+		})
+	}
+
+	t.Run("missing cells are skipped", func(t *testing.T) {
+		list := append(returnsList(times, 100, 80), timetable.NewMissingCell[float64](times[2]), timetable.NewCell(times[3], 100.0))
+		episodes := stats.DrawdownEpisodes(list, stats.Prices)
+		require.Len(t, episodes, 1)
+		assert.Equal(t, times[3], episodes[0].Recovery)
+	})
+
+	t.Run("max drawdown is the deepest episode", func(t *testing.T) {
+		returns := returnsList(times, 0.1, -0.2, 0.05, 0.2, -0.1)
+		deepest := 0.0
+		for _, episode := range stats.DrawdownEpisodes(returns, stats.Returns) {
+			deepest = min(deepest, episode.Depth)
+		}
+		assert.InDelta(t, stats.MaxDrawdown(returns), deepest, 1e-12)
+	})
+}
+
+func TestDrawdownColumns(t *testing.T) {
+	times := monthEnds(3)
+	table, err := timetable.NewNamed([]string{"AAA", "BBB"},
+		returnsList(times, 100, 80, 100),
+		returnsList(times, 10, 11, 9.9),
+	)
+	require.NoError(t, err)
+
+	underwater := stats.UnderwaterColumns(table, stats.Prices)
+	assert.Equal(t, []string{"AAA", "BBB"}, underwater.ColumnNames())
+	assert.Equal(t, times, underwater.UnderlyingTimes())
+	values := underwater.UnderlyingValues()
+	assert.InDeltaSlice(t, []float64{0, -0.2, 0}, values[0], 1e-12)
+	assert.InDeltaSlice(t, []float64{0, 0, -0.1}, values[1], 1e-12)
+
+	episodes := stats.DrawdownEpisodesColumns(table, stats.Prices)
+	require.Len(t, episodes, 2)
+	require.Len(t, episodes[0], 1)
+	assert.True(t, episodes[0][0].Recovered)
+	require.Len(t, episodes[1], 1)
+	assert.False(t, episodes[1][0].Recovered)
+	assert.Equal(t, time.Time{}, episodes[1][0].Recovery)
+}
@@ -0,0 +1,204 @@
+// Package stats computes portfolio performance statistics from periodic returns held in timetable lists and tables.
+//
+// Returns are simple returns, so 0.01 is a gain of one percent in a period.
+// Missing and NaN returns are left out of every statistic.
+package stats
+
+import (
+	"math"
+	"slices"
+	"time"
+
+	"github.com/portfoliotree/timetable"
+)
+
+// Options configures the statistics.
+type Options struct {
+	// PeriodsPerYear is the number of return periods in a year, such as 252 for trading days or 12 for months.
+	// When it is zero it is inferred from the times of the returns with PeriodsPerYear.
+	PeriodsPerYear float64
+
+	// RiskFreeRate is the annual rate subtracted from returns by Sharpe and used as the target return by Sortino.
+	RiskFreeRate float64
+}
+
+func (options Options) periodsPerYear(times []time.Time) float64 {
+	if options.PeriodsPerYear > 0 {
+		return options.PeriodsPerYear
+	}
+	return PeriodsPerYear(times)
+}
+
+// knownPeriodsPerYear are the usual numbers of periods in a year that inferred values are rounded to.
+var knownPeriodsPerYear = []float64{365, 252, 52, 12, 4, 1}
+
+// PeriodsPerYear infers the number of periods per year from sorted times by dividing the number of periods
+// by the number of years they span. Results within ten percent of 365, 252, 52, 12, 4 or 1 are rounded to that value.
+// It returns NaN when there are fewer than two times.
+func PeriodsPerYear(times []time.Time) float64 {
+	if len(times) < 2 {
+		return math.NaN()
+	}
+	years := times[len(times)-1].Sub(times[0]).Hours() / 24 / 365.25
+	if years <= 0 {
+		return math.NaN()
+	}
+	estimate := float64(len(times)-1) / years
+	for _, known := range knownPeriodsPerYear {
+		if math.Abs(estimate-known) <= known*0.1 {
+			return known
+		}
+	}
+	return estimate
+}
+
+// series holds the usable returns of a list in time order along with the times of every cell.
+type series struct {
+	times   []time.Time
+	returns []float64
+}
+
+func newSeries(list timetable.List[float64]) series {
+	cells := slices.Clone(list)
+	slices.SortStableFunc(cells, func(a, b timetable.Cell[float64]) int { return a.Time().Compare(b.Time()) })
+	var s series
+	for _, cell := range cells {
+		s.times = append(s.times, cell.Time())
+		if !cell.IsMissing() && !math.IsNaN(cell.Value()) {
+			s.returns = append(s.returns, cell.Value())
+		}
+	}
+	return s
+}
+
+// CAGR returns the compound annual growth rate of the returns.
+func CAGR(returns timetable.List[float64], options Options) float64 {
+	s := newSeries(returns)
+	return cagr(s.returns, options.periodsPerYear(s.times))
+}
+
+func cagr(returns []float64, periodsPerYear float64) float64 {
+	if len(returns) == 0 {
+		return math.NaN()
+	}
+	growth := 1 + timetable.CompoundReturn(returns)
+	return math.Pow(growth, periodsPerYear/float64(len(returns))) - 1
+}
+
+// AnnualizedVolatility returns the sample standard deviation of the returns scaled to a year.
+func AnnualizedVolatility(returns timetable.List[float64], options Options) float64 {
+	s := newSeries(returns)
+	return volatility(s.returns, options.periodsPerYear(s.times))
+}
+
+func volatility(returns []float64, periodsPerYear float64) float64 {
+	if len(returns) < 2 {
+		return math.NaN()
+	}
+	return timetable.Std(returns) * math.Sqrt(periodsPerYear)
+}
+
+// Sharpe returns the annualized Sharpe ratio: the mean return above the risk free rate divided by the standard deviation of the returns.
+// It is NaN when the returns do not vary.
+func Sharpe(returns timetable.List[float64], options Options) float64 {
+	s := newSeries(returns)
+	return sharpe(s.returns, options.periodsPerYear(s.times), options.RiskFreeRate)
+}
+
+func sharpe(returns []float64, periodsPerYear, riskFreeRate float64) float64 {
+	std := timetable.Std(returns)
+	if len(returns) < 2 || std == 0 {
+		return math.NaN()
+	}
+	return (timetable.Mean(returns) - riskFreeRate/periodsPerYear) / std * math.Sqrt(periodsPerYear)
+}
+
+// Sortino returns the annualized Sortino ratio: the mean return above the risk free rate divided by the downside deviation,
+// the root mean square of the returns below the risk free rate. It is NaN when no return is below the risk free rate.
+func Sortino(returns timetable.List[float64], options Options) float64 {
+	s := newSeries(returns)
+	return sortino(s.returns, options.periodsPerYear(s.times), options.RiskFreeRate)
+}
+
+func sortino(returns []float64, periodsPerYear, riskFreeRate float64) float64 {
+	target := riskFreeRate / periodsPerYear
+	var sumOfSquares float64
+	for _, r := range returns {
+		if r < target {
+			sumOfSquares += (r - target) * (r - target)
+		}
+	}
+	if sumOfSquares == 0 {
+		return math.NaN()
+	}
+	downside := math.Sqrt(sumOfSquares / float64(len(returns)))
+	return (timetable.Mean(returns) - target) / downside * math.Sqrt(periodsPerYear)
+}
+
+// MaxDrawdown returns the largest fall from a peak of the growth of the returns, as a negative fraction.
+func MaxDrawdown(returns timetable.List[float64]) float64 {
+	return timetable.MaxDrawdown(newSeries(returns).returns)
+}
+
+// Calmar returns the CAGR divided by the size of the max drawdown. It is NaN when there is no drawdown.
+func Calmar(returns timetable.List[float64], options Options) float64 {
+	s := newSeries(returns)
+	return calmar(s.returns, options.periodsPerYear(s.times))
+}
+
+func calmar(returns []float64, periodsPerYear float64) float64 {
+	drawdown := timetable.MaxDrawdown(returns)
+	if drawdown == 0 {
+		return math.NaN()
+	}
+	return cagr(returns, periodsPerYear) / -drawdown
+}
+
+// Summary holds every statistic for one series of returns.
+type Summary struct {
+	// Column is the name of the table column the summary is for. It is empty for lists and unnamed columns.
+	Column string
+
+	// Observations is the number of returns used, leaving out missing and NaN returns.
+	Observations   int
+	PeriodsPerYear float64
+
+	CAGR                 float64
+	AnnualizedVolatility float64
+	Sharpe               float64
+	Sortino              float64
+	MaxDrawdown          float64
+	Calmar               float64
+}
+
+// Summarize computes every statistic for the returns.
+func Summarize(returns timetable.List[float64], options Options) Summary {
+	s := newSeries(returns)
+	periodsPerYear := options.periodsPerYear(s.times)
+	return Summary{
+		Observations:         len(s.returns),
+		PeriodsPerYear:       periodsPerYear,
+		CAGR:                 cagr(s.returns, periodsPerYear),
+		AnnualizedVolatility: volatility(s.returns, periodsPerYear),
+		Sharpe:               sharpe(s.returns, periodsPerYear, options.RiskFreeRate),
+		Sortino:              sortino(s.returns, periodsPerYear, options.RiskFreeRate),
+		MaxDrawdown:          timetable.MaxDrawdown(s.returns),
+		Calmar:               calmar(s.returns, periodsPerYear),
+	}
+}
+
+// SummarizeColumns computes every statistic for each column of a table of returns.
+// When periods per year are inferred, they are inferred from the times of the whole table.
+func SummarizeColumns(returns *timetable.Compact[float64], options Options) []Summary {
+	if options.PeriodsPerYear <= 0 {
+		options.PeriodsPerYear = PeriodsPerYear(returns.UnderlyingTimes())
+	}
+	names := returns.ColumnNames()
+	summaries := make([]Summary, 0, returns.NumberOfColumns())
+	for column, list := range returns.Columns() {
+		summary := Summarize(list, options)
+		summary.Column = names[column]
+		summaries = append(summaries, summary)
+	}
+	return summaries
+}
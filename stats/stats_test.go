@@ -0,0 +1,137 @@
+package stats_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/portfoliotree/timetable"
+	"github.com/portfoliotree/timetable/stats"
+)
+
+func monthEnds(n int) []time.Time {
+	times := make([]time.Time, n)
+	for i := range times {
+		times[i] = time.Date(2021, time.Month(i+2), 0, 0, 0, 0, 0, time.UTC)
+	}
+	return times
+}
+
+func returnsList(times []time.Time, returns ...float64) timetable.List[float64] {
+	list := make(timetable.List[float64], len(returns))
+	for i, r := range returns {
+		list[i] = timetable.NewCell(times[i], r)
+	}
+	return list
+}
+
+func TestPeriodsPerYear(t *testing.T) {
+	start := time.Date(2021, time.January, 4, 0, 0, 0, 0, time.UTC)
+	var calendarDays, weekdays, weeks []time.Time
+	for day := range 730 {
+		t := start.AddDate(0, 0, day)
+		calendarDays = append(calendarDays, t)
+		if t.Weekday() != time.Saturday && t.Weekday() != time.Sunday {
+			weekdays = append(weekdays, t)
+		}
+		if day%7 == 0 {
+			weeks = append(weeks, t)
+		}
+	}
+	var quarters []time.Time
+	for i := range 12 {
+		quarters = append(quarters, time.Date(2021, time.Month(3*i+4), 0, 0, 0, 0, 0, time.UTC))
+	}
+
+	for _, tt := range []struct {
+		Name   string
+		Times  []time.Time
+		Result float64
+	}{
+		{Name: "calendar days", Times: calendarDays, Result: 365},
+		{Name: "weekdays", Times: weekdays, Result: 252},
+		{Name: "weeks", Times: weeks, Result: 52},
+		{Name: "months", Times: monthEnds(24), Result: 12},
+		{Name: "quarters", Times: quarters, Result: 4},
+		{Name: "irregular", Times: []time.Time{start, start.AddDate(0, 0, 20), start.AddDate(0, 0, 40)}, Result: 2 / (40 / 365.25)},
+	} {
+		t.Run(tt.Name, func(t *testing.T) {
+			assert.InDelta(t, tt.Result, stats.PeriodsPerYear(tt.Times), 1e-9)
+		})
+	}
+
+	assert.True(t, math.IsNaN(stats.PeriodsPerYear(monthEnds(1))))
+}
+
+func TestSummarize(t *testing.T) {
+	returns := returnsList(monthEnds(4), 0.1, -0.05, 0.02, 0.03)
+	mean := (0.1 - 0.05 + 0.02 + 0.03) / 4
+	std := timetable.Std([]float64{0.1, -0.05, 0.02, 0.03})
+	cagr := math.Pow(1.1*0.95*1.02*1.03, 3) - 1
+
+	summary := stats.Summarize(returns, stats.Options{})
+	assert.Equal(t, 4, summary.Observations)
+	assert.Equal(t, 12.0, summary.PeriodsPerYear)
+	assert.InDelta(t, cagr, summary.CAGR, 1e-12)
+	assert.InDelta(t, std*math.Sqrt(12), summary.AnnualizedVolatility, 1e-12)
+	assert.InDelta(t, mean/std*math.Sqrt(12), summary.Sharpe, 1e-12)
+	assert.InDelta(t, math.Sqrt(12), summary.Sortino, 1e-12)
+	assert.InDelta(t, -0.05, summary.MaxDrawdown, 1e-12)
+	assert.InDelta(t, cagr/0.05, summary.Calmar, 1e-12)
+
+	assert.Equal(t, summary.CAGR, stats.CAGR(returns, stats.Options{}))
+	assert.Equal(t, summary.AnnualizedVolatility, stats.AnnualizedVolatility(returns, stats.Options{}))
+	assert.Equal(t, summary.Sharpe, stats.Sharpe(returns, stats.Options{}))
+	assert.Equal(t, summary.Sortino, stats.Sortino(returns, stats.Options{}))
+	assert.Equal(t, summary.MaxDrawdown, stats.MaxDrawdown(returns))
+	assert.Equal(t, summary.Calmar, stats.Calmar(returns, stats.Options{}))
+
+	t.Run("explicit periods per year and risk free rate", func(t *testing.T) {
+		options := stats.Options{PeriodsPerYear: 4, RiskFreeRate: 0.04}
+		assert.InDelta(t, (mean-0.01)/std*2, stats.Sharpe(returns, options), 1e-12)
+		assert.InDelta(t, math.Pow(1.1*0.95*1.02*1.03, 1)-1, stats.CAGR(returns, options), 1e-12)
+	})
+
+	t.Run("missing and NaN returns are left out", func(t *testing.T) {
+		times := monthEnds(6)
+		withGaps := append(returnsList(times, 0.1, -0.05, math.NaN(), 0.02, 0.03), timetable.NewMissingCell[float64](times[5]))
+		summary := stats.Summarize(withGaps, stats.Options{PeriodsPerYear: 12})
+		assert.Equal(t, 4, summary.Observations)
+		assert.InDelta(t, cagr, summary.CAGR, 1e-12)
+	})
+
+	t.Run("unsorted", func(t *testing.T) {
+		unsorted := timetable.List[float64]{returns[3], returns[0], returns[2], returns[1]}
+		assert.Equal(t, summary, stats.Summarize(unsorted, stats.Options{}))
+	})
+
+	t.Run("no drawdown", func(t *testing.T) {
+		rising := returnsList(monthEnds(3), 0.01, 0.02, 0.03)
+		assert.True(t, math.IsNaN(stats.Calmar(rising, stats.Options{})))
+		assert.True(t, math.IsNaN(stats.Sortino(rising, stats.Options{})))
+	})
+}
+
+func TestSummarizeColumns(t *testing.T) {
+	times := monthEnds(4)
+	table, err := timetable.NewNamed([]string{"AAA", "BBB"},
+		returnsList(times, 0.1, -0.05, 0.02, 0.03),
+		returnsList(times, 0.01, 0.01, 0.01, 0.01),
+	)
+	require.NoError(t, err)
+
+	summaries := stats.SummarizeColumns(table, stats.Options{})
+	require.Len(t, summaries, 2)
+	assert.Equal(t, "AAA", summaries[0].Column)
+	assert.Equal(t, "BBB", summaries[1].Column)
+	assert.Equal(t, 12.0, summaries[1].PeriodsPerYear)
+	assert.InDelta(t, math.Pow(1.01, 12)-1, summaries[1].CAGR, 1e-12)
+	assert.True(t, math.IsNaN(summaries[1].Sharpe), "returns that do not vary have no Sharpe ratio")
+
+	expected := stats.Summarize(returnsList(times, 0.1, -0.05, 0.02, 0.03), stats.Options{})
+	expected.Column = "AAA"
+	assert.Equal(t, expected, summaries[0])
+}